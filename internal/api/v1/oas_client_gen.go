@@ -40,6 +40,12 @@ type Invoker interface {
 	//
 	// DELETE /api/v1/user/subs/token
 	DeleteUserTokenSub(ctx context.Context, params DeleteUserTokenSubParams) (DeleteUserTokenSubRes, error)
+	// GetRelatedTokens invokes getRelatedTokens operation.
+	//
+	// Get tokens which are the most often mentioned together with specified token.
+	//
+	// POST /api/v1/token/related
+	GetRelatedTokens(ctx context.Context, request *GetRelatedTokensRequest) (GetRelatedTokensRes, error)
 	// GetUserSearchQueries invokes getUserSearchQueries operation.
 	//
 	// Get user search queries.
@@ -395,6 +401,115 @@ func (c *Client) sendDeleteUserTokenSub(ctx context.Context, params DeleteUserTo
 	return result, nil
 }
 
+// GetRelatedTokens invokes getRelatedTokens operation.
+//
+// Get tokens which are the most often mentioned together with specified token.
+//
+// POST /api/v1/token/related
+func (c *Client) GetRelatedTokens(ctx context.Context, request *GetRelatedTokensRequest) (GetRelatedTokensRes, error) {
+	res, err := c.sendGetRelatedTokens(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendGetRelatedTokens(ctx context.Context, request *GetRelatedTokensRequest) (res GetRelatedTokensRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getRelatedTokens"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/v1/token/related"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GetRelatedTokensOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/v1/token/related"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeGetRelatedTokensRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:CookieAuth"
+			switch err := c.securityCookieAuth(ctx, GetRelatedTokensOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetRelatedTokensResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // GetUserSearchQueries invokes getUserSearchQueries operation.
 //
 // Get user search queries.
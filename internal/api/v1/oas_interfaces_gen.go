@@ -9,6 +9,10 @@ type DeleteUserTokenSubRes interface {
 	deleteUserTokenSubRes()
 }
 
+type GetRelatedTokensRes interface {
+	getRelatedTokensRes()
+}
+
 type GetUserSearchQueriesRes interface {
 	getUserSearchQueriesRes()
 }
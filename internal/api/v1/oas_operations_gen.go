@@ -8,6 +8,7 @@ type OperationName = string
 const (
 	DeleteUserSearchQueryOperation OperationName = "DeleteUserSearchQuery"
 	DeleteUserTokenSubOperation    OperationName = "DeleteUserTokenSub"
+	GetRelatedTokensOperation      OperationName = "GetRelatedTokens"
 	GetUserSearchQueriesOperation  OperationName = "GetUserSearchQueries"
 	GetUserTokenSubsOperation      OperationName = "GetUserTokenSubs"
 	LogoutUserOperation            OperationName = "LogoutUser"
@@ -168,24 +168,58 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 				}
 
-			case 't': // Prefix: "token/search"
+			case 't': // Prefix: "token/"
 
-				if l := len("token/search"); len(elem) >= l && elem[0:l] == "token/search" {
+				if l := len("token/"); len(elem) >= l && elem[0:l] == "token/" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
 				if len(elem) == 0 {
-					// Leaf node.
-					switch r.Method {
-					case "POST":
-						s.handleSearchTokenInfoRequest([0]string{}, elemIsEscaped, w, r)
-					default:
-						s.notAllowed(w, r, "POST")
+					break
+				}
+				switch elem[0] {
+				case 'r': // Prefix: "related"
+
+					if l := len("related"); len(elem) >= l && elem[0:l] == "related" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "POST":
+							s.handleGetRelatedTokensRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "POST")
+						}
+
+						return
+					}
+
+				case 's': // Prefix: "search"
+
+					if l := len("search"); len(elem) >= l && elem[0:l] == "search" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "POST":
+							s.handleSearchTokenInfoRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "POST")
+						}
+
+						return
 					}
 
-					return
 				}
 
 			case 'u': // Prefix: "user/"
@@ -470,28 +504,66 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 
 				}
 
-			case 't': // Prefix: "token/search"
+			case 't': // Prefix: "token/"
 
-				if l := len("token/search"); len(elem) >= l && elem[0:l] == "token/search" {
+				if l := len("token/"); len(elem) >= l && elem[0:l] == "token/" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
 				if len(elem) == 0 {
-					// Leaf node.
-					switch method {
-					case "POST":
-						r.name = SearchTokenInfoOperation
-						r.summary = "Get info for specified token"
-						r.operationID = "searchTokenInfo"
-						r.pathPattern = "/api/v1/token/search"
-						r.args = args
-						r.count = 0
-						return r, true
-					default:
-						return
+					break
+				}
+				switch elem[0] {
+				case 'r': // Prefix: "related"
+
+					if l := len("related"); len(elem) >= l && elem[0:l] == "related" {
+						elem = elem[l:]
+					} else {
+						break
 					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "POST":
+							r.name = GetRelatedTokensOperation
+							r.summary = "Get tokens which are the most often mentioned together with specified token"
+							r.operationID = "getRelatedTokens"
+							r.pathPattern = "/api/v1/token/related"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+
+				case 's': // Prefix: "search"
+
+					if l := len("search"); len(elem) >= l && elem[0:l] == "search" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "POST":
+							r.name = SearchTokenInfoOperation
+							r.summary = "Get info for specified token"
+							r.operationID = "searchTokenInfo"
+							r.pathPattern = "/api/v1/token/search"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+
 				}
 
 			case 'u': // Prefix: "user/"
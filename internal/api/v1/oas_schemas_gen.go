@@ -80,6 +80,92 @@ func (s *Error) SetError(val string) {
 	s.Error = val
 }
 
+type GetRelatedTokensInternalServerError Error
+
+func (*GetRelatedTokensInternalServerError) getRelatedTokensRes() {}
+
+type GetRelatedTokensNotFound Error
+
+func (*GetRelatedTokensNotFound) getRelatedTokensRes() {}
+
+type GetRelatedTokensOKApplicationJSON []RelatedToken
+
+func (*GetRelatedTokensOKApplicationJSON) getRelatedTokensRes() {}
+
+// Ref: #/components/schemas/GetRelatedTokensRequest
+type GetRelatedTokensRequest struct {
+	Token       string      `json:"token"`
+	Category    OptString   `json:"category"`
+	Start       time.Time   `json:"start"`
+	End         OptDateTime `json:"end"`
+	Limit       OptInt      `json:"limit"`
+	MinMentions OptInt64    `json:"min_mentions"`
+}
+
+// GetToken returns the value of Token.
+func (s *GetRelatedTokensRequest) GetToken() string {
+	return s.Token
+}
+
+// GetCategory returns the value of Category.
+func (s *GetRelatedTokensRequest) GetCategory() OptString {
+	return s.Category
+}
+
+// GetStart returns the value of Start.
+func (s *GetRelatedTokensRequest) GetStart() time.Time {
+	return s.Start
+}
+
+// GetEnd returns the value of End.
+func (s *GetRelatedTokensRequest) GetEnd() OptDateTime {
+	return s.End
+}
+
+// GetLimit returns the value of Limit.
+func (s *GetRelatedTokensRequest) GetLimit() OptInt {
+	return s.Limit
+}
+
+// GetMinMentions returns the value of MinMentions.
+func (s *GetRelatedTokensRequest) GetMinMentions() OptInt64 {
+	return s.MinMentions
+}
+
+// SetToken sets the value of Token.
+func (s *GetRelatedTokensRequest) SetToken(val string) {
+	s.Token = val
+}
+
+// SetCategory sets the value of Category.
+func (s *GetRelatedTokensRequest) SetCategory(val OptString) {
+	s.Category = val
+}
+
+// SetStart sets the value of Start.
+func (s *GetRelatedTokensRequest) SetStart(val time.Time) {
+	s.Start = val
+}
+
+// SetEnd sets the value of End.
+func (s *GetRelatedTokensRequest) SetEnd(val OptDateTime) {
+	s.End = val
+}
+
+// SetLimit sets the value of Limit.
+func (s *GetRelatedTokensRequest) SetLimit(val OptInt) {
+	s.Limit = val
+}
+
+// SetMinMentions sets the value of MinMentions.
+func (s *GetRelatedTokensRequest) SetMinMentions(val OptInt64) {
+	s.MinMentions = val
+}
+
+type GetRelatedTokensUnauthorized Error
+
+func (*GetRelatedTokensUnauthorized) getRelatedTokensRes() {}
+
 type GetUserSearchQueriesInternalServerError Error
 
 func (*GetUserSearchQueriesInternalServerError) getUserSearchQueriesRes() {}
@@ -183,6 +269,144 @@ func (o OptDateTime) Or(d time.Time) time.Time {
 	return d
 }
 
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptString returns new OptString with value set to v.
 func NewOptString(v string) OptString {
 	return OptString{
@@ -275,6 +499,65 @@ func (o OptUint64) Or(d uint64) uint64 {
 	return d
 }
 
+// Ref: #/components/schemas/RelatedToken
+type RelatedToken struct {
+	Token       string  `json:"token"`
+	DisplayName string  `json:"display_name"`
+	Mentions    int64   `json:"mentions"`
+	Lift        float64 `json:"lift"`
+	Pmi         float64 `json:"pmi"`
+}
+
+// GetToken returns the value of Token.
+func (s *RelatedToken) GetToken() string {
+	return s.Token
+}
+
+// GetDisplayName returns the value of DisplayName.
+func (s *RelatedToken) GetDisplayName() string {
+	return s.DisplayName
+}
+
+// GetMentions returns the value of Mentions.
+func (s *RelatedToken) GetMentions() int64 {
+	return s.Mentions
+}
+
+// GetLift returns the value of Lift.
+func (s *RelatedToken) GetLift() float64 {
+	return s.Lift
+}
+
+// GetPmi returns the value of Pmi.
+func (s *RelatedToken) GetPmi() float64 {
+	return s.Pmi
+}
+
+// SetToken sets the value of Token.
+func (s *RelatedToken) SetToken(val string) {
+	s.Token = val
+}
+
+// SetDisplayName sets the value of DisplayName.
+func (s *RelatedToken) SetDisplayName(val string) {
+	s.DisplayName = val
+}
+
+// SetMentions sets the value of Mentions.
+func (s *RelatedToken) SetMentions(val int64) {
+	s.Mentions = val
+}
+
+// SetLift sets the value of Lift.
+func (s *RelatedToken) SetLift(val float64) {
+	s.Lift = val
+}
+
+// SetPmi sets the value of Pmi.
+func (s *RelatedToken) SetPmi(val float64) {
+	s.Pmi = val
+}
+
 type SaveUserQueryBadRequest Error
 
 func (*SaveUserQueryBadRequest) saveUserQueryRes() {}
@@ -383,6 +666,43 @@ type SearchTokenInfoUnauthorized Error
 
 func (*SearchTokenInfoUnauthorized) searchTokenInfoRes() {}
 
+// Ref: #/components/schemas/SentimentDistribution
+type SentimentDistribution struct {
+	Positive int64 `json:"positive"`
+	Neutral  int64 `json:"neutral"`
+	Negative int64 `json:"negative"`
+}
+
+// GetPositive returns the value of Positive.
+func (s *SentimentDistribution) GetPositive() int64 {
+	return s.Positive
+}
+
+// GetNeutral returns the value of Neutral.
+func (s *SentimentDistribution) GetNeutral() int64 {
+	return s.Neutral
+}
+
+// GetNegative returns the value of Negative.
+func (s *SentimentDistribution) GetNegative() int64 {
+	return s.Negative
+}
+
+// SetPositive sets the value of Positive.
+func (s *SentimentDistribution) SetPositive(val int64) {
+	s.Positive = val
+}
+
+// SetNeutral sets the value of Neutral.
+func (s *SentimentDistribution) SetNeutral(val int64) {
+	s.Neutral = val
+}
+
+// SetNegative sets the value of Negative.
+func (s *SentimentDistribution) SetNegative(val int64) {
+	s.Negative = val
+}
+
 type SubscribeUserToTokenBadRequest Error
 
 func (*SubscribeUserToTokenBadRequest) subscribeUserToTokenRes() {}
@@ -405,6 +725,7 @@ type SubscribeUserToTokenRequest struct {
 	Category  string    `json:"category"`
 	Threshold float64   `json:"threshold"`
 	Method    OptString `json:"method"`
+	Signal    OptString `json:"signal"`
 }
 
 // GetToken returns the value of Token.
@@ -427,6 +748,11 @@ func (s *SubscribeUserToTokenRequest) GetMethod() OptString {
 	return s.Method
 }
 
+// GetSignal returns the value of Signal.
+func (s *SubscribeUserToTokenRequest) GetSignal() OptString {
+	return s.Signal
+}
+
 // SetToken sets the value of Token.
 func (s *SubscribeUserToTokenRequest) SetToken(val string) {
 	s.Token = val
@@ -447,6 +773,11 @@ func (s *SubscribeUserToTokenRequest) SetMethod(val OptString) {
 	s.Method = val
 }
 
+// SetSignal sets the value of Signal.
+func (s *SubscribeUserToTokenRequest) SetSignal(val OptString) {
+	s.Signal = val
+}
+
 // Ref: #/components/schemas/SubscribeUserToTokenResponse
 type SubscribeUserToTokenResponse struct {
 	ID string `json:"id"`
@@ -470,9 +801,10 @@ func (*SubscribeUserToTokenUnauthorized) subscribeUserToTokenRes() {}
 
 // Ref: #/components/schemas/TokenInfo
 type TokenInfo struct {
-	Token    string        `json:"token"`
-	Category string        `json:"category"`
-	Records  []TokenRecord `json:"records"`
+	Token       string        `json:"token"`
+	DisplayName string        `json:"display_name"`
+	Category    string        `json:"category"`
+	Records     []TokenRecord `json:"records"`
 }
 
 // GetToken returns the value of Token.
@@ -480,6 +812,11 @@ func (s *TokenInfo) GetToken() string {
 	return s.Token
 }
 
+// GetDisplayName returns the value of DisplayName.
+func (s *TokenInfo) GetDisplayName() string {
+	return s.DisplayName
+}
+
 // GetCategory returns the value of Category.
 func (s *TokenInfo) GetCategory() string {
 	return s.Category
@@ -495,6 +832,11 @@ func (s *TokenInfo) SetToken(val string) {
 	s.Token = val
 }
 
+// SetDisplayName sets the value of DisplayName.
+func (s *TokenInfo) SetDisplayName(val string) {
+	s.DisplayName = val
+}
+
 // SetCategory sets the value of Category.
 func (s *TokenInfo) SetCategory(val string) {
 	s.Category = val
@@ -532,10 +874,16 @@ func (s *TokenRecord) SetFeatures(val TokenRecordFeatures) {
 }
 
 type TokenRecordFeatures struct {
-	Interest           int64   `json:"interest"`
-	InterestNormalized float64 `json:"interest_normalized"`
-	InterestCategory   float64 `json:"interest_category"`
-	Sentiment          int16   `json:"sentiment"`
+	Interest               int64                 `json:"interest"`
+	InterestNormalized     float64               `json:"interest_normalized"`
+	InterestCategory       float64               `json:"interest_category"`
+	DocFrequency           int64                 `json:"doc_frequency"`
+	DocFrequencyNormalized float64               `json:"doc_frequency_normalized"`
+	DocFrequencyCategory   float64               `json:"doc_frequency_category"`
+	Sentiment              int16                 `json:"sentiment"`
+	SentimentScore         float64               `json:"sentiment_score"`
+	SentimentConfidence    OptFloat64            `json:"sentiment_confidence"`
+	SentimentDistribution  SentimentDistribution `json:"sentiment_distribution"`
 }
 
 // GetInterest returns the value of Interest.
@@ -553,11 +901,41 @@ func (s *TokenRecordFeatures) GetInterestCategory() float64 {
 	return s.InterestCategory
 }
 
+// GetDocFrequency returns the value of DocFrequency.
+func (s *TokenRecordFeatures) GetDocFrequency() int64 {
+	return s.DocFrequency
+}
+
+// GetDocFrequencyNormalized returns the value of DocFrequencyNormalized.
+func (s *TokenRecordFeatures) GetDocFrequencyNormalized() float64 {
+	return s.DocFrequencyNormalized
+}
+
+// GetDocFrequencyCategory returns the value of DocFrequencyCategory.
+func (s *TokenRecordFeatures) GetDocFrequencyCategory() float64 {
+	return s.DocFrequencyCategory
+}
+
 // GetSentiment returns the value of Sentiment.
 func (s *TokenRecordFeatures) GetSentiment() int16 {
 	return s.Sentiment
 }
 
+// GetSentimentScore returns the value of SentimentScore.
+func (s *TokenRecordFeatures) GetSentimentScore() float64 {
+	return s.SentimentScore
+}
+
+// GetSentimentConfidence returns the value of SentimentConfidence.
+func (s *TokenRecordFeatures) GetSentimentConfidence() OptFloat64 {
+	return s.SentimentConfidence
+}
+
+// GetSentimentDistribution returns the value of SentimentDistribution.
+func (s *TokenRecordFeatures) GetSentimentDistribution() SentimentDistribution {
+	return s.SentimentDistribution
+}
+
 // SetInterest sets the value of Interest.
 func (s *TokenRecordFeatures) SetInterest(val int64) {
 	s.Interest = val
@@ -573,11 +951,41 @@ func (s *TokenRecordFeatures) SetInterestCategory(val float64) {
 	s.InterestCategory = val
 }
 
+// SetDocFrequency sets the value of DocFrequency.
+func (s *TokenRecordFeatures) SetDocFrequency(val int64) {
+	s.DocFrequency = val
+}
+
+// SetDocFrequencyNormalized sets the value of DocFrequencyNormalized.
+func (s *TokenRecordFeatures) SetDocFrequencyNormalized(val float64) {
+	s.DocFrequencyNormalized = val
+}
+
+// SetDocFrequencyCategory sets the value of DocFrequencyCategory.
+func (s *TokenRecordFeatures) SetDocFrequencyCategory(val float64) {
+	s.DocFrequencyCategory = val
+}
+
 // SetSentiment sets the value of Sentiment.
 func (s *TokenRecordFeatures) SetSentiment(val int16) {
 	s.Sentiment = val
 }
 
+// SetSentimentScore sets the value of SentimentScore.
+func (s *TokenRecordFeatures) SetSentimentScore(val float64) {
+	s.SentimentScore = val
+}
+
+// SetSentimentConfidence sets the value of SentimentConfidence.
+func (s *TokenRecordFeatures) SetSentimentConfidence(val OptFloat64) {
+	s.SentimentConfidence = val
+}
+
+// SetSentimentDistribution sets the value of SentimentDistribution.
+func (s *TokenRecordFeatures) SetSentimentDistribution(val SentimentDistribution) {
+	s.SentimentDistribution = val
+}
+
 type UpdateUserTokenSubBadRequest Error
 
 func (*UpdateUserTokenSubBadRequest) updateUserTokenSubRes() {}
@@ -592,9 +1000,10 @@ func (*UpdateUserTokenSubNotFound) updateUserTokenSubRes() {}
 
 // Ref: #/components/schemas/UpdateUserTokenSubRequest
 type UpdateUserTokenSubRequest struct {
-	ID        string  `json:"id"`
-	Threshold float64 `json:"threshold"`
-	Method    string  `json:"method"`
+	ID        string    `json:"id"`
+	Threshold float64   `json:"threshold"`
+	Method    string    `json:"method"`
+	Signal    OptString `json:"signal"`
 }
 
 // GetID returns the value of ID.
@@ -612,6 +1021,11 @@ func (s *UpdateUserTokenSubRequest) GetMethod() string {
 	return s.Method
 }
 
+// GetSignal returns the value of Signal.
+func (s *UpdateUserTokenSubRequest) GetSignal() OptString {
+	return s.Signal
+}
+
 // SetID sets the value of ID.
 func (s *UpdateUserTokenSubRequest) SetID(val string) {
 	s.ID = val
@@ -627,6 +1041,11 @@ func (s *UpdateUserTokenSubRequest) SetMethod(val string) {
 	s.Method = val
 }
 
+// SetSignal sets the value of Signal.
+func (s *UpdateUserTokenSubRequest) SetSignal(val OptString) {
+	s.Signal = val
+}
+
 // Ref: #/components/schemas/UpdateUserTokenSubResponse
 type UpdateUserTokenSubResponse struct {
 	CurrentInterest  float64 `json:"current_interest"`
@@ -736,8 +1155,10 @@ func (s *UserSearchQuery) SetSearchDate(val time.Time) {
 type UserTokenSub struct {
 	ID               string    `json:"id"`
 	Token            string    `json:"token"`
+	DisplayName      string    `json:"display_name"`
 	Category         string    `json:"category"`
 	Method           string    `json:"method"`
+	Signal           string    `json:"signal"`
 	Threshold        float64   `json:"threshold"`
 	CurrentInterest  float64   `json:"current_interest"`
 	PreviousInterest float64   `json:"previous_interest"`
@@ -754,6 +1175,11 @@ func (s *UserTokenSub) GetToken() string {
 	return s.Token
 }
 
+// GetDisplayName returns the value of DisplayName.
+func (s *UserTokenSub) GetDisplayName() string {
+	return s.DisplayName
+}
+
 // GetCategory returns the value of Category.
 func (s *UserTokenSub) GetCategory() string {
 	return s.Category
@@ -764,6 +1190,11 @@ func (s *UserTokenSub) GetMethod() string {
 	return s.Method
 }
 
+// GetSignal returns the value of Signal.
+func (s *UserTokenSub) GetSignal() string {
+	return s.Signal
+}
+
 // GetThreshold returns the value of Threshold.
 func (s *UserTokenSub) GetThreshold() float64 {
 	return s.Threshold
@@ -794,6 +1225,11 @@ func (s *UserTokenSub) SetToken(val string) {
 	s.Token = val
 }
 
+// SetDisplayName sets the value of DisplayName.
+func (s *UserTokenSub) SetDisplayName(val string) {
+	s.DisplayName = val
+}
+
 // SetCategory sets the value of Category.
 func (s *UserTokenSub) SetCategory(val string) {
 	s.Category = val
@@ -804,6 +1240,11 @@ func (s *UserTokenSub) SetMethod(val string) {
 	s.Method = val
 }
 
+// SetSignal sets the value of Signal.
+func (s *UserTokenSub) SetSignal(val string) {
+	s.Signal = val
+}
+
 // SetThreshold sets the value of Threshold.
 func (s *UserTokenSub) SetThreshold(val float64) {
 	s.Threshold = val
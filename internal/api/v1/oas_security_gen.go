@@ -35,6 +35,7 @@ func findAuthorization(h http.Header, prefix string) (string, bool) {
 var operationRolesCookieAuth = map[string][]string{
 	DeleteUserSearchQueryOperation: []string{},
 	DeleteUserTokenSubOperation:    []string{},
+	GetRelatedTokensOperation:      []string{},
 	GetUserSearchQueriesOperation:  []string{},
 	GetUserTokenSubsOperation:      []string{},
 	LogoutUserOperation:            []string{},
@@ -20,6 +20,12 @@ type Handler interface {
 	//
 	// DELETE /api/v1/user/subs/token
 	DeleteUserTokenSub(ctx context.Context, params DeleteUserTokenSubParams) (DeleteUserTokenSubRes, error)
+	// GetRelatedTokens implements getRelatedTokens operation.
+	//
+	// Get tokens which are the most often mentioned together with specified token.
+	//
+	// POST /api/v1/token/related
+	GetRelatedTokens(ctx context.Context, req *GetRelatedTokensRequest) (GetRelatedTokensRes, error)
 	// GetUserSearchQueries implements getUserSearchQueries operation.
 	//
 	// Get user search queries.
@@ -31,6 +31,15 @@ func (UnimplementedHandler) DeleteUserTokenSub(ctx context.Context, params Delet
 	return r, ht.ErrNotImplemented
 }
 
+// GetRelatedTokens implements getRelatedTokens operation.
+//
+// Get tokens which are the most often mentioned together with specified token.
+//
+// POST /api/v1/token/related
+func (UnimplementedHandler) GetRelatedTokens(ctx context.Context, req *GetRelatedTokensRequest) (r GetRelatedTokensRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetUserSearchQueries implements getUserSearchQueries operation.
 //
 // Get user search queries.
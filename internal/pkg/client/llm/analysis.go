@@ -9,9 +9,20 @@ import (
 	"strings"
 )
 
-// SentimentAnalysisResponse response from sentiment analysis
+// SentimentMentions numbers of positive, neutral and negative mentions of the token in the context
+type SentimentMentions struct {
+	Positive int64 `json:"positive"`
+	Neutral  int64 `json:"neutral"`
+	Negative int64 `json:"negative"`
+}
+
+// SentimentAnalysisResponse response from sentiment analysis, score, confidence and mentions
+// are optional, because not every service returns them
 type SentimentAnalysisResponse struct {
-	Sentiment int16 `json:"sentiment"`
+	Sentiment  int16              `json:"sentiment"`
+	Score      *float64           `json:"score,omitempty"`
+	Confidence *float64           `json:"confidence,omitempty"`
+	Mentions   *SentimentMentions `json:"mentions,omitempty"`
 }
 
 // SentimentAnalysisRequest request for sentiment analysis
@@ -0,0 +1,258 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrInvalidItem is returned within results of batch items which can not be analyzed
+var ErrInvalidItem = errors.New("invalid batch item")
+
+// BatchSentimentItem token context for batch sentiment analysis
+type BatchSentimentItem struct {
+	Token   string `json:"token"`
+	Context string `json:"context"`
+}
+
+// BatchSentimentAnalysisRequest request for batch sentiment analysis
+type BatchSentimentAnalysisRequest struct {
+	Items []BatchSentimentItem `json:"items"`
+}
+
+// BatchSentimentResult sentiment analysis result of a single token, Error is the text of the item error
+// as it is sent over the wire, use Err to get the error itself
+type BatchSentimentResult struct {
+	Token      string             `json:"token"`
+	Sentiment  int16              `json:"sentiment"`
+	Score      *float64           `json:"score,omitempty"`
+	Confidence *float64           `json:"confidence,omitempty"`
+	Mentions   *SentimentMentions `json:"mentions,omitempty"`
+	Error      string             `json:"error,omitempty"`
+
+	// err is set if the item was analyzed by this process, so callers can check it with errors.Is
+	err error
+}
+
+// BatchSentimentAnalysisResponse response from batch sentiment analysis
+type BatchSentimentAnalysisResponse struct {
+	Results []BatchSentimentResult `json:"results"`
+}
+
+// NewBatchSentimentResult creates batch result of the token from sentiment analysis response
+func NewBatchSentimentResult(token string, resp *SentimentAnalysisResponse) BatchSentimentResult {
+	return BatchSentimentResult{
+		Token:      token,
+		Sentiment:  resp.Sentiment,
+		Score:      resp.Score,
+		Confidence: resp.Confidence,
+		Mentions:   resp.Mentions,
+	}
+}
+
+// NewBatchSentimentError creates failed batch result of the token, the error is kept as it is
+func NewBatchSentimentError(token string, err error) BatchSentimentResult {
+	return BatchSentimentResult{
+		Token: token,
+		Error: err.Error(),
+		err:   err,
+	}
+}
+
+// Err returns error of the item, nil if the item was analyzed successfully
+func (r *BatchSentimentResult) Err() error {
+	switch {
+	case r.err != nil:
+		return r.err
+	case r.Error != "":
+		return errors.New(r.Error)
+	default:
+		return nil
+	}
+}
+
+// Response returns batch result as sentiment analysis response
+func (r *BatchSentimentResult) Response() *SentimentAnalysisResponse {
+	return &SentimentAnalysisResponse{
+		Sentiment:  r.Sentiment,
+		Score:      r.Score,
+		Confidence: r.Confidence,
+		Mentions:   r.Mentions,
+	}
+}
+
+// Validate validate batch sentiment analysis item
+func (i *BatchSentimentItem) Validate() error {
+	var errs []error
+
+	if strings.TrimSpace(i.Token) == "" {
+		errs = append(errs, errors.New("token is required"))
+	}
+
+	if strings.TrimSpace(i.Context) == "" {
+		errs = append(errs, errors.New("context is required"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate validate batch sentiment analysis request, items are validated by SplitInvalid,
+// so a single invalid item does not fail the whole batch
+func (r *BatchSentimentAnalysisRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return errors.New("items are required")
+	}
+
+	return nil
+}
+
+// SplitInvalid returns items which can be analyzed and failed results of invalid items
+func (r *BatchSentimentAnalysisRequest) SplitInvalid() ([]BatchSentimentItem, []BatchSentimentResult) {
+	var (
+		valid   = make([]BatchSentimentItem, 0, len(r.Items))
+		invalid []BatchSentimentResult
+	)
+
+	for i, item := range r.Items {
+		if err := item.Validate(); err != nil {
+			invalid = append(invalid, NewBatchSentimentError(item.Token, fmt.Errorf("%w %d: %w", ErrInvalidItem, i, err)))
+			continue
+		}
+
+		valid = append(valid, item)
+	}
+
+	return valid, invalid
+}
+
+// ByToken returns results mapped by token
+func (r *BatchSentimentAnalysisResponse) ByToken() map[string]BatchSentimentResult {
+	results := make(map[string]BatchSentimentResult, len(r.Results))
+	for _, res := range r.Results {
+		results[res.Token] = res
+	}
+
+	return results
+}
+
+// BatchSentimentAnalysis perform sentiment analysis of many token contexts, items are split into
+// batches of configured size, falls back to single requests if service does not support batching
+func (c *Client) BatchSentimentAnalysis(
+	ctx context.Context,
+	req *BatchSentimentAnalysisRequest,
+) (*BatchSentimentAnalysisResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("wrong request: %w", err)
+	}
+
+	batchSize := c.cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	valid, invalid := req.SplitInvalid()
+
+	resp := &BatchSentimentAnalysisResponse{
+		Results: append(make([]BatchSentimentResult, 0, len(req.Items)), invalid...),
+	}
+
+	for items := range slices.Chunk(valid, batchSize) {
+		results, err := c.analyzeBatch(ctx, items)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Results = append(resp.Results, results...)
+	}
+
+	return resp, nil
+}
+
+// analyzeBatch analyzes a single batch, using single requests if batching is not supported
+func (c *Client) analyzeBatch(ctx context.Context, items []BatchSentimentItem) ([]BatchSentimentResult, error) {
+	if time.Now().UnixNano() >= c.batchUnsupportedUntil.Load() {
+		llmResp, err := c.makeRequestJSON(ctx, http.MethodPost, c.endpoints.BatchSentimentAnalysis,
+			&BatchSentimentAnalysisRequest{Items: items},
+		)
+
+		switch {
+		case err == nil:
+			var resp BatchSentimentAnalysisResponse
+			if err := json.Unmarshal(llmResp, &resp); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+			}
+
+			return resp.Results, nil
+		case !isBatchUnsupported(err):
+			return nil, fmt.Errorf("failed to send request to %s: %w", c.endpoints.BatchSentimentAnalysis, err)
+		}
+
+		// remember it for a while, so next batches go straight to single requests,
+		// and probe batch endpoint again later in case the service is updated
+		retryInterval := c.cfg.BatchRetryInterval
+		if retryInterval <= 0 {
+			retryInterval = defaultBatchRetryInterval
+		}
+
+		c.batchUnsupportedUntil.Store(time.Now().Add(retryInterval).UnixNano())
+	}
+
+	return c.analyzeSingle(ctx, items), nil
+}
+
+// analyzeSingle analyzes every item with a single request, errors are returned within results
+func (c *Client) analyzeSingle(ctx context.Context, items []BatchSentimentItem) []BatchSentimentResult {
+	concurrency := c.cfg.FallbackConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultFallbackConcurrency
+	}
+
+	var (
+		results = make([]BatchSentimentResult, len(items))
+		g       errgroup.Group
+	)
+
+	g.SetLimit(concurrency)
+
+	for i, item := range items {
+		g.Go(func() error {
+			resp, err := c.SentimentAnalysis(ctx, &SentimentAnalysisRequest{
+				Context: item.Context,
+			})
+			if err != nil {
+				results[i] = NewBatchSentimentError(item.Token, err)
+				return nil
+			}
+
+			results[i] = NewBatchSentimentResult(item.Token, resp) // every goroutine writes only its own item
+
+			return nil
+		})
+	}
+
+	_ = g.Wait() // errors are returned within results
+
+	return results
+}
+
+// isBatchUnsupported checks if error means that service does not support batch endpoint
+func isBatchUnsupported(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	switch statusErr.Code {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,288 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubServer stand-in of sentiment analysis service, batch endpoint responds with batchCode if it is set
+type stubServer struct {
+	batchCode    int
+	batchCalls   atomic.Int64
+	singleCalls  atomic.Int64
+	failContexts map[string]int
+}
+
+func (s *stubServer) start(t *testing.T) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/analyze-sentiment/batch", func(w http.ResponseWriter, r *http.Request) {
+		s.batchCalls.Add(1)
+
+		if s.batchCode != 0 {
+			w.WriteHeader(s.batchCode)
+			return
+		}
+
+		var req BatchSentimentAnalysisRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var resp BatchSentimentAnalysisResponse
+		for _, item := range req.Items {
+			if code, ok := s.failContexts[item.Context]; ok {
+				resp.Results = append(resp.Results, BatchSentimentResult{
+					Token: item.Token,
+					Error: fmt.Sprintf("failed with code %d", code),
+				})
+
+				continue
+			}
+
+			resp.Results = append(resp.Results, BatchSentimentResult{Token: item.Token, Sentiment: 1})
+		}
+
+		writeJSON(w, resp)
+	})
+	mux.HandleFunc("/analyze-sentiment", func(w http.ResponseWriter, r *http.Request) {
+		s.singleCalls.Add(1)
+
+		var req SentimentAnalysisRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if code, ok := s.failContexts[req.Context]; ok {
+			w.WriteHeader(code)
+			return
+		}
+
+		writeJSON(w, SentimentAnalysisResponse{Sentiment: -1})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return NewClient(&Config{URL: srv.URL, Timeout: time.Second, BatchSize: 2, FallbackConcurrency: 2})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v) // small bodies are sent with Content-Length
+}
+
+func newBatchRequest(tokens ...string) *BatchSentimentAnalysisRequest {
+	req := &BatchSentimentAnalysisRequest{}
+	for _, token := range tokens {
+		req.Items = append(req.Items, BatchSentimentItem{Token: token, Context: "context of " + token})
+	}
+
+	return req
+}
+
+func TestBatchSentimentAnalysisBatchEndpoint(t *testing.T) {
+	srv := &stubServer{}
+	client := srv.start(t)
+
+	resp, err := client.BatchSentimentAnalysis(context.Background(), newBatchRequest("a", "b", "c"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+
+	for i, token := range []string{"a", "b", "c"} {
+		res := resp.Results[i]
+		if res.Token != token || res.Sentiment != 1 || res.Err() != nil {
+			t.Fatalf("unexpected result %d: %+v", i, res)
+		}
+	}
+
+	// 3 items are split into batches of 2
+	if calls := srv.batchCalls.Load(); calls != 2 {
+		t.Fatalf("expected 2 batch requests, got %d", calls)
+	}
+
+	if calls := srv.singleCalls.Load(); calls != 0 {
+		t.Fatalf("expected no single requests, got %d", calls)
+	}
+}
+
+func TestBatchSentimentAnalysisUnsupported(t *testing.T) {
+	for _, code := range []int{http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			srv := &stubServer{batchCode: code}
+			client := srv.start(t)
+
+			resp, err := client.BatchSentimentAnalysis(context.Background(), newBatchRequest("a", "b", "c"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, res := range resp.Results {
+				if res.Sentiment != -1 || res.Err() != nil {
+					t.Fatalf("expected result of single request, got %+v", res)
+				}
+			}
+
+			if client.batchUnsupportedUntil.Load() <= time.Now().UnixNano() {
+				t.Fatal("expected batch endpoint to be marked as unsupported")
+			}
+
+			// next batches go straight to single requests
+			if calls := srv.batchCalls.Load(); calls != 1 {
+				t.Fatalf("expected 1 batch request, got %d", calls)
+			}
+
+			if calls := srv.singleCalls.Load(); calls != 3 {
+				t.Fatalf("expected 3 single requests, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestBatchSentimentAnalysisUnsupportedProbe(t *testing.T) {
+	srv := &stubServer{batchCode: http.StatusNotFound}
+	client := srv.start(t)
+
+	if _, err := client.BatchSentimentAnalysis(context.Background(), newBatchRequest("a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// retry interval is over, so batch endpoint is probed again
+	client.batchUnsupportedUntil.Store(time.Now().Add(-time.Second).UnixNano())
+
+	if _, err := client.BatchSentimentAnalysis(context.Background(), newBatchRequest("b")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls := srv.batchCalls.Load(); calls != 2 {
+		t.Fatalf("expected 2 batch requests, got %d", calls)
+	}
+
+	if until := time.Unix(0, client.batchUnsupportedUntil.Load()); time.Until(until) < 5*time.Minute {
+		t.Fatalf("expected batch endpoint to be marked as unsupported for default interval, got until %s", until)
+	}
+}
+
+func TestBatchSentimentAnalysisServerError(t *testing.T) {
+	srv := &stubServer{batchCode: http.StatusInternalServerError}
+	client := srv.start(t)
+
+	_, err := client.BatchSentimentAnalysis(context.Background(), newBatchRequest("a"))
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status error with code 500, got %v", err)
+	}
+
+	if client.batchUnsupportedUntil.Load() != 0 {
+		t.Fatal("server error must not mark batch endpoint as unsupported")
+	}
+
+	if calls := srv.singleCalls.Load(); calls != 0 {
+		t.Fatalf("expected no single requests, got %d", calls)
+	}
+}
+
+func TestBatchSentimentAnalysisItemErrors(t *testing.T) {
+	t.Run("batch", func(t *testing.T) {
+		srv := &stubServer{failContexts: map[string]int{"context of b": http.StatusInternalServerError}}
+		client := srv.start(t)
+
+		resp, err := client.BatchSentimentAnalysis(context.Background(), newBatchRequest("a", "b"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results := resp.ByToken()
+		if res := results["a"]; res.Err() != nil {
+			t.Fatalf("unexpected error of item a: %v", res.Err())
+		}
+
+		res := results["b"]
+		if res.Err() == nil || res.Error != "failed with code 500" {
+			t.Fatalf("expected error of item b from service, got %+v", res)
+		}
+	})
+
+	t.Run("single", func(t *testing.T) {
+		srv := &stubServer{
+			batchCode:    http.StatusNotFound,
+			failContexts: map[string]int{"context of b": http.StatusServiceUnavailable},
+		}
+		client := srv.start(t)
+
+		resp, err := client.BatchSentimentAnalysis(context.Background(), newBatchRequest("a", "b"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results := resp.ByToken()
+		if res := results["a"]; res.Err() != nil {
+			t.Fatalf("unexpected error of item a: %v", res.Err())
+		}
+
+		res := results["b"]
+
+		var statusErr *StatusError
+		if !errors.As(res.Err(), &statusErr) || statusErr.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status error of item b with code 503, got %v", res.Err())
+		}
+
+		if res.Error != res.Err().Error() {
+			t.Fatalf("expected error text %q, got %q", res.Err().Error(), res.Error)
+		}
+	})
+}
+
+func TestBatchSentimentAnalysisInvalidItems(t *testing.T) {
+	srv := &stubServer{}
+	client := srv.start(t)
+
+	req := newBatchRequest("a", "b")
+	req.Items[1].Context = " "
+	req.Items = append(req.Items, BatchSentimentItem{Context: "context without token"})
+
+	resp, err := client.BatchSentimentAnalysis(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := resp.ByToken()
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	if res := results["a"]; res.Sentiment != 1 || res.Err() != nil {
+		t.Fatalf("unexpected result of valid item: %+v", res)
+	}
+
+	for _, token := range []string{"b", ""} {
+		if res := results[token]; !errors.Is(res.Err(), ErrInvalidItem) {
+			t.Fatalf("expected invalid item error of %q, got %v", token, res.Err())
+		}
+	}
+
+	// only the valid item is sent
+	if calls := srv.batchCalls.Load(); calls != 1 {
+		t.Fatalf("expected 1 batch request, got %d", calls)
+	}
+
+	if _, err := client.BatchSentimentAnalysis(context.Background(), &BatchSentimentAnalysisRequest{}); err == nil {
+		t.Fatal("expected error of empty request")
+	}
+}
@@ -0,0 +1,170 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/pkg/logger"
+	"github.com/keenywheels/backend/pkg/logger/zap"
+)
+
+// ErrOpen is returned without calling LLM service while the circuit is open
+var ErrOpen = errors.New("llm circuit breaker is open")
+
+// circuit states
+const (
+	stateClosed   = "closed"
+	stateOpen     = "open"
+	stateHalfOpen = "half_open"
+)
+
+// IClient defines the interface of the protected LLM client
+type IClient interface {
+	SentimentAnalysis(ctx context.Context, req *llm.SentimentAnalysisRequest) (*llm.SentimentAnalysisResponse, error)
+	BatchSentimentAnalysis(
+		ctx context.Context,
+		req *llm.BatchSentimentAnalysisRequest,
+	) (*llm.BatchSentimentAnalysisResponse, error)
+}
+
+// Client protects LLM client with circuit breaker, so requests fail fast while LLM service is down
+type Client struct {
+	l      logger.Logger
+	client IClient
+	cfg    Config
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+}
+
+// New creates a new circuit breaker in front of the LLM client
+func New(client IClient, cfg Config, opts ...Option) *Client {
+	fixConfig(&cfg)
+
+	c := &Client{
+		l:      zap.New(),
+		client: client,
+		cfg:    cfg,
+		state:  stateClosed,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// SentimentAnalysis perform sentiment analysis if the circuit is not open
+func (c *Client) SentimentAnalysis(
+	ctx context.Context,
+	req *llm.SentimentAnalysisRequest,
+) (*llm.SentimentAnalysisResponse, error) {
+	if err := c.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.SentimentAnalysis(ctx, req)
+	c.report(err)
+
+	return resp, err
+}
+
+// BatchSentimentAnalysis perform batch sentiment analysis if the circuit is not open,
+// clients analyzing items with single requests return their errors within results,
+// so every item is reported as a separate request
+func (c *Client) BatchSentimentAnalysis(
+	ctx context.Context,
+	req *llm.BatchSentimentAnalysisRequest,
+) (*llm.BatchSentimentAnalysisResponse, error) {
+	if err := c.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.BatchSentimentAnalysis(ctx, req)
+	if err != nil || len(resp.Results) == 0 {
+		c.report(err)
+		return resp, err
+	}
+
+	for i := range resp.Results {
+		c.report(resp.Results[i].Err())
+	}
+
+	return resp, nil
+}
+
+// allow checks whether the request can be sent, after open timeout only one probe request is allowed
+func (c *Client) allow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case stateOpen:
+		if time.Since(c.openedAt) < c.cfg.OpenTimeout {
+			return ErrOpen
+		}
+
+		c.setState(stateHalfOpen)
+
+		return nil
+	case stateHalfOpen:
+		// probe request is already in progress
+		return ErrOpen
+	default:
+		return nil
+	}
+}
+
+// report updates the circuit state based on the request result
+func (c *Client) report(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, llm.ErrInvalidItem):
+		// request was interrupted or not sent, so it says nothing about LLM service -> allow next probe
+		if c.state == stateHalfOpen {
+			c.openedAt = time.Time{}
+			c.setState(stateOpen)
+		}
+	case err == nil || !isFailure(err):
+		c.failures = 0
+		c.setState(stateClosed)
+	default:
+		c.failures++
+
+		if c.state == stateHalfOpen || c.failures >= c.cfg.FailureThreshold {
+			c.openedAt = time.Now()
+			c.setState(stateOpen)
+		}
+	}
+}
+
+// setState changes the circuit state, should be called under lock
+func (c *Client) setState(state string) {
+	if c.state == state {
+		return
+	}
+
+	c.l.Warnf("llm circuit breaker state changed: %s -> %s", c.state, state)
+	c.state = state
+}
+
+// isFailure checks if error means that LLM service is unavailable,
+// client errors mean that service is up, so they do not count
+func isFailure(err error) bool {
+	var statusErr *llm.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= http.StatusInternalServerError || statusErr.Code == http.StatusTooManyRequests
+	}
+
+	return true
+}
@@ -0,0 +1,103 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/pkg/logger/zap"
+)
+
+// newOutageServer returns server which does not support batch endpoint and fails every single request
+func newOutageServer(t *testing.T, singleCalls *atomic.Int64) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/analyze-sentiment/batch", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/analyze-sentiment", func(w http.ResponseWriter, _ *http.Request) {
+		singleCalls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func batchRequest(tokens ...string) *llm.BatchSentimentAnalysisRequest {
+	req := &llm.BatchSentimentAnalysisRequest{}
+	for _, token := range tokens {
+		req.Items = append(req.Items, llm.BatchSentimentItem{Token: token, Context: "context of " + token})
+	}
+
+	return req
+}
+
+func TestBatchItemFailuresOpenCircuit(t *testing.T) {
+	var singleCalls atomic.Int64
+
+	srv := newOutageServer(t, &singleCalls)
+	client := New(
+		llm.NewClient(&llm.Config{URL: srv.URL, Timeout: time.Second, FallbackConcurrency: 1}),
+		Config{FailureThreshold: 3, OpenTimeout: time.Hour},
+		WithLogger(zap.New(zap.LogPath(filepath.Join(t.TempDir(), "app.log")))),
+	)
+
+	resp, err := client.BatchSentimentAnalysis(context.Background(), batchRequest("a", "b", "c"))
+	if err != nil {
+		t.Fatalf("unexpected error of the batch: %v", err)
+	}
+
+	for _, res := range resp.Results {
+		var statusErr *llm.StatusError
+		if !errors.As(res.Err(), &statusErr) || statusErr.Code != http.StatusServiceUnavailable {
+			t.Fatalf("item %s: expected typed status error, got %v", res.Token, res.Err())
+		}
+	}
+
+	if client.state != stateOpen {
+		t.Fatalf("expected open circuit after %d failed items, got %s", len(resp.Results), client.state)
+	}
+
+	calls := singleCalls.Load()
+
+	_, err = client.BatchSentimentAnalysis(context.Background(), batchRequest("d"))
+	if !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected ErrOpen, got %v", err)
+	}
+
+	if singleCalls.Load() != calls {
+		t.Fatal("request was sent while the circuit is open")
+	}
+}
+
+func TestBatchSuccessClosesCircuit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"token":"a","sentiment":1}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := New(
+		llm.NewClient(&llm.Config{URL: srv.URL, Timeout: time.Second}),
+		Config{FailureThreshold: 3, OpenTimeout: time.Hour},
+	)
+	client.failures = 2
+
+	if _, err := client.BatchSentimentAnalysis(context.Background(), batchRequest("a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.state != stateClosed || client.failures != 0 {
+		t.Fatalf("expected closed circuit without failures, got %s with %d failures", client.state, client.failures)
+	}
+}
@@ -0,0 +1,31 @@
+package breaker
+
+import "time"
+
+// default values
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+// Config holds circuit breaker configuration
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// FailureThreshold is the number of consecutive failures after which the circuit opens
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// OpenTimeout is how long the circuit stays open before a probe request is allowed
+	OpenTimeout time.Duration `mapstructure:"open_timeout"`
+}
+
+// fixConfig sets default values for the Config if they are not provided
+func fixConfig(cfg *Config) {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+}
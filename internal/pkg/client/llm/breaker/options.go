@@ -0,0 +1,12 @@
+package breaker
+
+import "github.com/keenywheels/backend/pkg/logger"
+
+type Option func(*Client)
+
+// WithLogger sets the logger for the Client
+func WithLogger(l logger.Logger) Option {
+	return func(c *Client) {
+		c.l = l
+	}
+}
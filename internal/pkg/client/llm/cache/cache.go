@@ -0,0 +1,254 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/pkg/logger"
+	"github.com/keenywheels/backend/pkg/logger/zap"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// keyPrefix is the prefix of cache keys in redis, v2 values hold the whole response
+	// instead of sentiment only, so old values are not read and expire by ttl
+	keyPrefix = "sentiment:v2:"
+
+	// meterName is the instrumentation name of cache metrics
+	meterName = "github.com/keenywheels/backend/internal/pkg/client/llm/cache"
+)
+
+// lookup results
+const (
+	resultMemoryHit = "memory_hit"
+	resultRedisHit  = "redis_hit"
+	resultMiss      = "miss"
+)
+
+// IClient defines the interface of the cached LLM client
+type IClient interface {
+	SentimentAnalysis(ctx context.Context, req *llm.SentimentAnalysisRequest) (*llm.SentimentAnalysisResponse, error)
+	BatchSentimentAnalysis(
+		ctx context.Context,
+		req *llm.BatchSentimentAnalysisRequest,
+	) (*llm.BatchSentimentAnalysisResponse, error)
+}
+
+// IRedis defines the interface of the redis tier
+type IRedis interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Stats holds cache hit/miss counters
+type Stats struct {
+	MemoryHits int64
+	RedisHits  int64
+	Misses     int64
+}
+
+// Client caches sentiment analysis results of the LLM client by hash of normalized context
+type Client struct {
+	l      logger.Logger
+	client IClient
+	cfg    Config
+
+	memory *lru
+	redis  IRedis
+
+	memoryHits atomic.Int64
+	redisHits  atomic.Int64
+	misses     atomic.Int64
+
+	meterProvider metric.MeterProvider
+	lookups       metric.Int64Counter
+}
+
+// New creates a new caching client in front of the LLM client
+func New(client IClient, cfg Config, opts ...Option) (*Client, error) {
+	fixConfig(&cfg)
+
+	c := &Client{
+		l:             zap.New(),
+		client:        client,
+		cfg:           cfg,
+		memory:        newLRU(cfg.Size, cfg.TTL),
+		meterProvider: otel.GetMeterProvider(),
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	lookups, err := c.meterProvider.Meter(meterName).Int64Counter(
+		"processor.llm.cache.lookups",
+		metric.WithDescription("Number of sentiment cache lookups by result"),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache lookups counter: %w", err)
+	}
+
+	c.lookups = lookups
+
+	return c, nil
+}
+
+// Stats returns current hit/miss counters of the cache
+func (c *Client) Stats() Stats {
+	return Stats{
+		MemoryHits: c.memoryHits.Load(),
+		RedisHits:  c.redisHits.Load(),
+		Misses:     c.misses.Load(),
+	}
+}
+
+// SentimentAnalysis perform sentiment analysis, cached result is returned if exists
+func (c *Client) SentimentAnalysis(
+	ctx context.Context,
+	req *llm.SentimentAnalysisRequest,
+) (*llm.SentimentAnalysisResponse, error) {
+	key := contextKey(req.Context)
+
+	if cached, ok := c.lookup(ctx, key); ok {
+		return &cached, nil
+	}
+
+	resp, err := c.client.SentimentAnalysis(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(ctx, key, resp)
+
+	return resp, nil
+}
+
+// BatchSentimentAnalysis perform batch sentiment analysis, only contexts without cached result are sent
+func (c *Client) BatchSentimentAnalysis(
+	ctx context.Context,
+	req *llm.BatchSentimentAnalysisRequest,
+) (*llm.BatchSentimentAnalysisResponse, error) {
+	var (
+		resp = &llm.BatchSentimentAnalysisResponse{
+			Results: make([]llm.BatchSentimentResult, 0, len(req.Items)),
+		}
+		missed = &llm.BatchSentimentAnalysisRequest{}
+		keys   = make(map[string]string)
+	)
+
+	for _, item := range req.Items {
+		key := contextKey(item.Context)
+
+		if cached, ok := c.lookup(ctx, key); ok {
+			resp.Results = append(resp.Results, llm.NewBatchSentimentResult(item.Token, &cached))
+
+			continue
+		}
+
+		keys[item.Token] = key
+		missed.Items = append(missed.Items, item)
+	}
+
+	if len(missed.Items) == 0 {
+		return resp, nil
+	}
+
+	missedResp, err := c.client.BatchSentimentAnalysis(ctx, missed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, res := range missedResp.Results {
+		if key, ok := keys[res.Token]; ok && res.Err() == nil {
+			c.store(ctx, key, res.Response())
+		}
+
+		resp.Results = append(resp.Results, res)
+	}
+
+	return resp, nil
+}
+
+// lookup looks for the sentiment in memory and then in redis, redis errors are treated as miss
+func (c *Client) lookup(ctx context.Context, key string) (llm.SentimentAnalysisResponse, bool) {
+	if cached, ok := c.memory.get(key); ok {
+		c.count(ctx, &c.memoryHits, resultMemoryHit)
+		return cached, true
+	}
+
+	if c.redis != nil {
+		cached, ok, err := c.getRedis(ctx, key)
+		if err != nil {
+			c.l.Warnf("failed to get sentiment from redis: %v", err)
+		}
+
+		if ok {
+			c.memory.set(key, cached)
+			c.count(ctx, &c.redisHits, resultRedisHit)
+
+			return cached, true
+		}
+	}
+
+	c.count(ctx, &c.misses, resultMiss)
+
+	return llm.SentimentAnalysisResponse{}, false
+}
+
+// store saves the sentiment in all cache tiers
+func (c *Client) store(ctx context.Context, key string, resp *llm.SentimentAnalysisResponse) {
+	c.memory.set(key, *resp)
+
+	if c.redis != nil {
+		value, err := json.Marshal(resp)
+		if err != nil {
+			c.l.Warnf("failed to encode sentiment: %v", err)
+			return
+		}
+
+		if err := c.redis.Set(ctx, keyPrefix+key, value, c.cfg.RedisTTL); err != nil {
+			c.l.Warnf("failed to save sentiment to redis: %v", err)
+		}
+	}
+}
+
+// getRedis returns the sentiment from redis tier
+func (c *Client) getRedis(ctx context.Context, key string) (llm.SentimentAnalysisResponse, bool, error) {
+	var resp llm.SentimentAnalysisResponse
+
+	value, ok, err := c.redis.Get(ctx, keyPrefix+key)
+	if err != nil || !ok {
+		return resp, false, err
+	}
+
+	if err := json.Unmarshal(value, &resp); err != nil {
+		return resp, false, fmt.Errorf("got invalid cached value %q: %w", value, err)
+	}
+
+	return resp, true, nil
+}
+
+// count increments the counter and lookups metric
+func (c *Client) count(ctx context.Context, counter *atomic.Int64, result string) {
+	counter.Add(1)
+	c.lookups.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+}
+
+// contextKey returns the cache key of the context: sha256 of lowercased context with collapsed whitespaces
+func contextKey(context string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(context)), " ")
+	hash := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,33 @@
+package cache
+
+import "time"
+
+// default values
+const (
+	defaultSize     = 10000
+	defaultTTL      = 24 * time.Hour
+	defaultRedisTTL = 7 * 24 * time.Hour
+)
+
+// Config holds sentiment cache configuration
+type Config struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Size     int           `mapstructure:"size"`
+	TTL      time.Duration `mapstructure:"ttl"`
+	RedisTTL time.Duration `mapstructure:"redis_ttl"`
+}
+
+// fixConfig sets default values for the Config if they are not provided
+func fixConfig(cfg *Config) {
+	if cfg.Size <= 0 {
+		cfg.Size = defaultSize
+	}
+
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+
+	if cfg.RedisTTL <= 0 {
+		cfg.RedisTTL = defaultRedisTTL
+	}
+}
@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"github.com/keenywheels/backend/pkg/logger"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type Option func(*Client)
+
+// WithLogger sets the logger for the Client
+func WithLogger(l logger.Logger) Option {
+	return func(c *Client) {
+		c.l = l
+	}
+}
+
+// WithRedis enables redis tier of the cache
+func WithRedis(r IRedis) Option {
+	return func(c *Client) {
+		c.redis = r
+	}
+}
+
+// WithMeterProvider sets the meter provider used for cache metrics
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *Client) {
+		c.meterProvider = mp
+	}
+}
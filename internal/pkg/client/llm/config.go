@@ -2,9 +2,25 @@ package llm
 
 import "time"
 
+// default values
+const (
+	defaultBatchSize           = 100
+	defaultFallbackConcurrency = 8
+	defaultBatchRetryInterval  = 10 * time.Minute
+)
+
 // Config LLM client configuration
 type Config struct {
 	Token   string        `mapstructure:"token"`
 	URL     string        `mapstructure:"url"`
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// BatchSize limits the number of contexts sent in one batch request
+	BatchSize int `mapstructure:"batch_size"`
+
+	// FallbackConcurrency limits concurrent single requests used when batching is not supported
+	FallbackConcurrency int `mapstructure:"fallback_concurrency"`
+
+	// BatchRetryInterval is how long single requests are used before batch endpoint is probed again
+	BatchRetryInterval time.Duration `mapstructure:"batch_retry_interval"`
 }
@@ -0,0 +1,141 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/pkg/logger"
+	"github.com/keenywheels/backend/pkg/logger/zap"
+)
+
+// IClient defines the interface of sentiment analysis client
+type IClient interface {
+	SentimentAnalysis(ctx context.Context, req *llm.SentimentAnalysisRequest) (*llm.SentimentAnalysisResponse, error)
+	BatchSentimentAnalysis(
+		ctx context.Context,
+		req *llm.BatchSentimentAnalysisRequest,
+	) (*llm.BatchSentimentAnalysisResponse, error)
+}
+
+// Client analyzes sentiment with the primary client and uses the secondary one if it fails
+type Client struct {
+	l         logger.Logger
+	primary   IClient
+	secondary IClient
+}
+
+// New creates a new client with fallback
+func New(primary IClient, secondary IClient, opts ...Option) *Client {
+	c := &Client{
+		l:         zap.New(),
+		primary:   primary,
+		secondary: secondary,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// SentimentAnalysis perform sentiment analysis with the primary client, falls back to the secondary one
+func (c *Client) SentimentAnalysis(
+	ctx context.Context,
+	req *llm.SentimentAnalysisRequest,
+) (*llm.SentimentAnalysisResponse, error) {
+	resp, err := c.primary.SentimentAnalysis(ctx, req)
+	if err == nil || ctx.Err() != nil {
+		return resp, err
+	}
+
+	c.l.Warnf("primary sentiment analysis failed -> use fallback: %v", err)
+
+	// errors of both clients are kept, so callers can check them, e.g. for open circuit of the primary
+	resp, fallbackErr := c.secondary.SentimentAnalysis(ctx, req)
+	if fallbackErr != nil {
+		return nil, errors.Join(err, fallbackErr)
+	}
+
+	return resp, nil
+}
+
+// BatchSentimentAnalysis perform batch sentiment analysis with the primary client,
+// failed items are analyzed with the secondary one
+func (c *Client) BatchSentimentAnalysis(
+	ctx context.Context,
+	req *llm.BatchSentimentAnalysisRequest,
+) (*llm.BatchSentimentAnalysisResponse, error) {
+	resp, err := c.primary.BatchSentimentAnalysis(ctx, req)
+	if ctx.Err() != nil {
+		return resp, err
+	}
+
+	if err != nil {
+		c.l.Warnf("primary batch sentiment analysis failed -> use fallback: %v", err)
+
+		fallbackResp, fallbackErr := c.secondary.BatchSentimentAnalysis(ctx, req)
+		if fallbackErr != nil {
+			return nil, errors.Join(err, fallbackErr)
+		}
+
+		return fallbackResp, nil
+	}
+
+	// collect failed items
+	var (
+		contexts = make(map[string]string, len(req.Items))
+		failed   = &llm.BatchSentimentAnalysisRequest{}
+		idx      = make(map[string]int)
+	)
+
+	for _, item := range req.Items {
+		contexts[item.Token] = item.Context
+	}
+
+	for i, res := range resp.Results {
+		// invalid items would fail the same way in fallback
+		if res.Err() == nil || errors.Is(res.Err(), llm.ErrInvalidItem) {
+			continue
+		}
+
+		idx[res.Token] = i
+		failed.Items = append(failed.Items, llm.BatchSentimentItem{
+			Token:   res.Token,
+			Context: contexts[res.Token],
+		})
+	}
+
+	if len(failed.Items) == 0 {
+		return resp, nil
+	}
+
+	c.l.Warnf("primary sentiment analysis failed for %d tokens -> use fallback", len(failed.Items))
+
+	// failed items keep errors of both clients
+	fallbackResp, err := c.secondary.BatchSentimentAnalysis(ctx, failed)
+	if err != nil {
+		for _, i := range idx {
+			resp.Results[i] = llm.NewBatchSentimentError(resp.Results[i].Token, errors.Join(resp.Results[i].Err(), err))
+		}
+
+		return resp, nil
+	}
+
+	for _, res := range fallbackResp.Results {
+		i, ok := idx[res.Token]
+		if !ok {
+			continue
+		}
+
+		if res.Err() != nil {
+			res = llm.NewBatchSentimentError(res.Token, errors.Join(resp.Results[i].Err(), res.Err()))
+		}
+
+		resp.Results[i] = res
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,27 @@
+package lexicon
+
+// default values
+const (
+	defaultThreshold      = 0.5
+	defaultNegationWindow = 2
+)
+
+// Config holds lexicon analyzer configuration
+type Config struct {
+	// Threshold is the minimal absolute average polarity of the context to be considered not neutral
+	Threshold float64 `mapstructure:"threshold"`
+
+	// NegationWindow is the number of words after negation which polarity is inverted
+	NegationWindow int `mapstructure:"negation_window"`
+}
+
+// fixConfig sets default values for the Config if they are not provided
+func fixConfig(cfg *Config) {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultThreshold
+	}
+
+	if cfg.NegationWindow <= 0 {
+		cfg.NegationWindow = defaultNegationWindow
+	}
+}
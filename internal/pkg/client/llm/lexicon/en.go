@@ -0,0 +1,101 @@
+package lexicon
+
+// English polarity dictionary, words are stemmed on load
+var English = map[string]float64{
+	// positive
+	"good":        2,
+	"great":       3,
+	"excellent":   3,
+	"amazing":     3,
+	"awesome":     3,
+	"perfect":     3,
+	"best":        3,
+	"better":      2,
+	"love":        3,
+	"like":        1,
+	"nice":        2,
+	"cheap":       1,
+	"fast":        1,
+	"reliable":    2,
+	"comfortable": 2,
+	"recommend":   2,
+	"happy":       2,
+	"glad":        2,
+	"thanks":      1,
+	"success":     2,
+	"growth":      1,
+	"profit":      2,
+	"discount":    1,
+	"beautiful":   2,
+	"useful":      2,
+	"interesting": 1,
+	"safe":        1,
+	"honest":      2,
+	"win":         2,
+	"fine":        1,
+
+	// negative
+	"bad":          -2,
+	"terrible":     -3,
+	"awful":        -3,
+	"horrible":     -3,
+	"worst":        -3,
+	"worse":        -2,
+	"hate":         -3,
+	"poor":         -2,
+	"broken":       -2,
+	"expensive":    -1,
+	"slow":         -1,
+	"scam":         -3,
+	"fraud":        -3,
+	"fake":         -2,
+	"problem":      -2,
+	"issue":        -1,
+	"error":        -1,
+	"bug":          -1,
+	"fail":         -2,
+	"failure":      -2,
+	"disappointed": -2,
+	"sad":          -2,
+	"angry":        -2,
+	"dangerous":    -2,
+	"crash":        -2,
+	"crisis":       -2,
+	"loss":         -2,
+	"scandal":      -2,
+	"useless":      -2,
+	"defect":       -2,
+}
+
+// englishNegations words which invert polarity of the following words
+var englishNegations = map[string]struct{}{
+	"not":     {},
+	"no":      {},
+	"never":   {},
+	"neither": {},
+	"nor":     {},
+	"without": {},
+	"dont":    {},
+	"doesnt":  {},
+	"didnt":   {},
+	"isnt":    {},
+	"arent":   {},
+	"wasnt":   {},
+	"cant":    {},
+	"wont":    {},
+}
+
+// englishIntensifiers words which strengthen or weaken polarity of the following words
+var englishIntensifiers = map[string]float64{
+	"very":       1.5,
+	"really":     1.5,
+	"extremely":  2,
+	"absolutely": 2,
+	"totally":    1.5,
+	"so":         1.3,
+	"too":        1.3,
+	"quite":      1.2,
+	"slightly":   0.5,
+	"somewhat":   0.7,
+	"bit":        0.5,
+}
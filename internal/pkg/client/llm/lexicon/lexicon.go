@@ -0,0 +1,252 @@
+package lexicon
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/stemmer"
+)
+
+// sentiment values, same as returned by sntmnt service
+const (
+	sentimentNegative = int16(-1)
+	sentimentNeutral  = int16(0)
+	sentimentPositive = int16(1)
+)
+
+// contextSeparator separates occurrences of the token in the context built by the pipeline
+const contextSeparator = ";"
+
+// Analyzer is an offline sentiment analyzer based on polarity dictionaries, it works with
+// the same token contexts as LLM client, so it can be used instead of it
+type Analyzer struct {
+	cfg Config
+
+	// polarity maps stemmed word to its polarity
+	polarity     map[string]float64
+	negations    map[string]struct{}
+	intensifiers map[string]float64
+}
+
+// New creates a new lexicon analyzer with russian and english dictionaries
+func New(cfg Config) (*Analyzer, error) {
+	fixConfig(&cfg)
+
+	a := &Analyzer{
+		cfg:          cfg,
+		polarity:     make(map[string]float64, len(Russian)+len(English)),
+		negations:    make(map[string]struct{}, len(russianNegations)+len(englishNegations)),
+		intensifiers: make(map[string]float64, len(russianIntensifiers)+len(englishIntensifiers)),
+	}
+
+	for _, dict := range []map[string]float64{Russian, English} {
+		for word, polarity := range dict {
+			stem, err := stemmer.DefaultStemmer.Stem(word)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stem dictionary word %s: %w", word, err)
+			}
+
+			// words with the same stem keep the strongest polarity
+			if prev, ok := a.polarity[stem]; !ok || math.Abs(polarity) > math.Abs(prev) {
+				a.polarity[stem] = polarity
+			}
+		}
+	}
+
+	for _, dict := range []map[string]struct{}{russianNegations, englishNegations} {
+		for word := range dict {
+			a.negations[word] = struct{}{}
+		}
+	}
+
+	for _, dict := range []map[string]float64{russianIntensifiers, englishIntensifiers} {
+		for word, mult := range dict {
+			a.intensifiers[word] = mult
+		}
+	}
+
+	return a, nil
+}
+
+// SentimentAnalysis perform sentiment analysis of the context
+func (a *Analyzer) SentimentAnalysis(
+	_ context.Context,
+	req *llm.SentimentAnalysisRequest,
+) (*llm.SentimentAnalysisResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("wrong request: %w", err)
+	}
+
+	return a.analyze(req.Context), nil
+}
+
+// BatchSentimentAnalysis perform sentiment analysis of many token contexts
+func (a *Analyzer) BatchSentimentAnalysis(
+	_ context.Context,
+	req *llm.BatchSentimentAnalysisRequest,
+) (*llm.BatchSentimentAnalysisResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("wrong request: %w", err)
+	}
+
+	valid, invalid := req.SplitInvalid()
+
+	resp := &llm.BatchSentimentAnalysisResponse{
+		Results: append(make([]llm.BatchSentimentResult, 0, len(req.Items)), invalid...),
+	}
+
+	for _, item := range valid {
+		resp.Results = append(resp.Results, llm.NewBatchSentimentResult(item.Token, a.analyze(item.Context)))
+	}
+
+	return resp, nil
+}
+
+// Score returns average polarity of sentiment words of the context, negation inverts polarity
+// of the following words within the window, intensifiers multiply polarity of the next word
+func (a *Analyzer) Score(text string) float64 {
+	var (
+		sum   float64
+		count int
+	)
+
+	// every occurrence is analyzed separately, so modifiers do not leak into the next one
+	for _, occurrence := range strings.Split(text, contextSeparator) {
+		occurrenceSum, occurrenceCount := a.occurrencePolarity(occurrence)
+
+		sum += occurrenceSum
+		count += occurrenceCount
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return sum / float64(count)
+}
+
+// analyze returns sentiment and score of the whole context, every occurrence is counted as a mention
+// with its own polarity, confidence is not returned since dictionary scores are not calibrated
+func (a *Analyzer) analyze(text string) *llm.SentimentAnalysisResponse {
+	var (
+		sum      float64
+		count    int
+		mentions llm.SentimentMentions
+	)
+
+	for _, occurrence := range strings.Split(text, contextSeparator) {
+		if strings.TrimSpace(occurrence) == "" {
+			continue
+		}
+
+		occurrenceSum, occurrenceCount := a.occurrencePolarity(occurrence)
+
+		sum += occurrenceSum
+		count += occurrenceCount
+
+		var score float64
+		if occurrenceCount > 0 {
+			score = occurrenceSum / float64(occurrenceCount)
+		}
+
+		switch a.sentiment(score) {
+		case sentimentPositive:
+			mentions.Positive++
+		case sentimentNegative:
+			mentions.Negative++
+		default:
+			mentions.Neutral++
+		}
+	}
+
+	var score float64
+	if count > 0 {
+		score = sum / float64(count)
+	}
+
+	return &llm.SentimentAnalysisResponse{
+		Sentiment: a.sentiment(score),
+		Score:     &score,
+		Mentions:  &mentions,
+	}
+}
+
+// occurrencePolarity returns sum of polarities and number of sentiment words of a single occurrence
+func (a *Analyzer) occurrencePolarity(occurrence string) (float64, int) {
+	var (
+		sum        float64
+		count      int
+		negated    int
+		multiplier = 1.0
+	)
+
+	for _, field := range strings.Fields(occurrence) {
+		word := normalize(field)
+		if word == "" {
+			continue
+		}
+
+		if _, ok := a.negations[word]; ok {
+			negated = a.cfg.NegationWindow
+			continue
+		}
+
+		if mult, ok := a.intensifiers[word]; ok {
+			multiplier *= mult
+			continue
+		}
+
+		stem, err := stemmer.DefaultStemmer.Stem(word)
+		if err != nil {
+			stem = word
+		}
+
+		if polarity, ok := a.polarity[stem]; ok {
+			polarity *= multiplier
+			if negated > 0 {
+				polarity = -polarity
+			}
+
+			sum += polarity
+			count++
+
+			negated = 0
+		} else if negated > 0 {
+			negated--
+		}
+
+		multiplier = 1.0
+	}
+
+	return sum, count
+}
+
+// sentiment converts polarity score to sentiment value
+func (a *Analyzer) sentiment(score float64) int16 {
+	switch {
+	case score >= a.cfg.Threshold:
+		return sentimentPositive
+	case score <= -a.cfg.Threshold:
+		return sentimentNegative
+	default:
+		return sentimentNeutral
+	}
+}
+
+// normalize lowercases the word and removes all non-letter characters
+func normalize(word string) string {
+	builder := strings.Builder{}
+	builder.Grow(len(word))
+
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			builder.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,129 @@
+package lexicon
+
+// Russian polarity dictionary, words are stemmed on load
+var Russian = map[string]float64{
+	// positive
+	"хороший":       2,
+	"хорошо":        2,
+	"отличный":      3,
+	"отлично":       3,
+	"прекрасный":    3,
+	"прекрасно":     3,
+	"замечательный": 3,
+	"замечательно":  3,
+	"великолепный":  3,
+	"лучший":        3,
+	"лучше":         2,
+	"нравится":      2,
+	"люблю":         3,
+	"любимый":       2,
+	"удобный":       2,
+	"удобно":        2,
+	"качественный":  2,
+	"надежный":      2,
+	"выгодный":      2,
+	"выгодно":       2,
+	"дешевый":       1,
+	"недорого":      1,
+	"быстрый":       1,
+	"быстро":        1,
+	"новый":         1,
+	"рекомендую":    2,
+	"доволен":       2,
+	"довольна":      2,
+	"спасибо":       1,
+	"успех":         2,
+	"успешный":      2,
+	"рост":          1,
+	"выгода":        2,
+	"скидка":        1,
+	"подарок":       1,
+	"радость":       2,
+	"рад":           2,
+	"красивый":      2,
+	"интересный":    1,
+	"полезный":      2,
+	"идеальный":     3,
+	"супер":         3,
+	"круто":         2,
+	"класс":         2,
+	"восторг":       3,
+	"честный":       2,
+	"безопасный":    1,
+	"исправный":     1,
+	"целый":         1,
+
+	// negative
+	"плохой":         -2,
+	"плохо":          -2,
+	"ужасный":        -3,
+	"ужасно":         -3,
+	"отвратительный": -3,
+	"худший":         -3,
+	"хуже":           -2,
+	"кошмар":         -3,
+	"провал":         -3,
+	"обман":          -3,
+	"мошенник":       -3,
+	"развод":         -2,
+	"дорогой":        -1,
+	"дорого":         -1,
+	"медленный":      -1,
+	"медленно":       -1,
+	"сломан":         -2,
+	"сломанный":      -2,
+	"битый":          -2,
+	"ржавый":         -2,
+	"брак":           -2,
+	"дефект":         -2,
+	"неисправный":    -2,
+	"проблема":       -2,
+	"ошибка":         -1,
+	"жалоба":         -2,
+	"недоволен":      -2,
+	"недовольна":     -2,
+	"разочарован":    -2,
+	"разочарование":  -2,
+	"ненавижу":       -3,
+	"грустный":       -2,
+	"грустно":        -2,
+	"страшный":       -2,
+	"опасный":        -2,
+	"авария":         -2,
+	"кризис":         -2,
+	"падение":        -1,
+	"убыток":         -2,
+	"штраф":          -1,
+	"скандал":        -2,
+	"жаль":           -1,
+	"отстой":         -3,
+	"фигня":          -2,
+	"ерунда":         -2,
+}
+
+// russianNegations words which invert polarity of the following words
+var russianNegations = map[string]struct{}{
+	"не":        {},
+	"нет":       {},
+	"ни":        {},
+	"никогда":   {},
+	"нисколько": {},
+	"без":       {},
+}
+
+// russianIntensifiers words which strengthen or weaken polarity of the following words
+var russianIntensifiers = map[string]float64{
+	"очень":      1.5,
+	"крайне":     2,
+	"слишком":    1.5,
+	"совсем":     1.5,
+	"абсолютно":  2,
+	"невероятно": 2,
+	"супер":      1.5,
+	"самый":      1.5,
+	"довольно":   1.2,
+	"весьма":     1.3,
+	"чуть":       0.5,
+	"немного":    0.5,
+	"слегка":     0.5,
+}
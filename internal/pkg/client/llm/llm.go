@@ -8,13 +8,27 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/keenywheels/backend/pkg/httpclient"
 )
 
 // endpoints LLM service endpoints
 type endpoints struct {
-	SentimentAnalysis string
+	SentimentAnalysis      string
+	BatchSentimentAnalysis string
+}
+
+// StatusError is returned when LLM service responds with not ok status code
+type StatusError struct {
+	Path string
+	Code int
+	Body string
+}
+
+// Error implements error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("got not ok status code from %s: code=%d body=%s", e.Path, e.Code, e.Body)
 }
 
 // Client LLM client
@@ -22,6 +36,10 @@ type Client struct {
 	client    *http.Client
 	endpoints endpoints
 	cfg       *Config
+
+	// batchUnsupportedUntil is unix time in nanoseconds until which batch endpoint is not used,
+	// it is set when the service responds that batch endpoint is not supported
+	batchUnsupportedUntil atomic.Int64
 }
 
 // NewClient create new LLM client
@@ -29,7 +47,8 @@ func NewClient(cfg *Config) *Client {
 	client := httpclient.DefaultClient(cfg.Timeout)
 
 	endpoints := endpoints{
-		SentimentAnalysis: "/analyze-sentiment",
+		SentimentAnalysis:      "/analyze-sentiment",
+		BatchSentimentAnalysis: "/analyze-sentiment/batch",
 	}
 
 	return &Client{
@@ -64,6 +83,17 @@ func (c *Client) makeRequestJSON(ctx context.Context, method string, path string
 	}
 	defer resp.Body.Close()
 
+	// check status first, so callers can rely on status code even for responses without body
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+
+		return nil, &StatusError{
+			Path: path,
+			Code: resp.StatusCode,
+			Body: string(body),
+		}
+	}
+
 	if resp.ContentLength <= 0 {
 		return nil, fmt.Errorf("got empty response from %s: code=%d", path, resp.StatusCode)
 	}
@@ -73,10 +103,5 @@ func (c *Client) makeRequestJSON(ctx context.Context, method string, path string
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf("got not ok status code from %s: code=%d body=%s",
-			path, resp.StatusCode, string(bytes))
-	}
-
 	return bytes, nil
 }
@@ -0,0 +1,72 @@
+package openai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// budget limits the number of tokens spent per minute
+type budget struct {
+	mu          sync.Mutex
+	limit       int
+	used        int
+	windowStart time.Time
+}
+
+// newBudget creates a new budget, nil budget has no limit
+func newBudget(limit int) *budget {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &budget{limit: limit}
+}
+
+// wait blocks until the tokens can be spent in the current minute and reserves them,
+// request bigger than the whole budget is allowed only in an empty window
+func (b *budget) wait(ctx context.Context, tokens int) error {
+	if b == nil {
+		return nil
+	}
+
+	for {
+		b.mu.Lock()
+
+		now := time.Now()
+		if now.Sub(b.windowStart) >= time.Minute {
+			b.windowStart = now
+			b.used = 0
+		}
+
+		if b.used == 0 || b.used+tokens <= b.limit {
+			b.used += tokens
+			b.mu.Unlock()
+
+			return nil
+		}
+
+		wait := b.windowStart.Add(time.Minute).Sub(now)
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// adjust corrects reserved tokens with the actual usage
+func (b *budget) adjust(reserved int, actual int) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.used = max(b.used+actual-reserved, 0)
+	b.mu.Unlock()
+}
@@ -0,0 +1,65 @@
+package openai
+
+import "time"
+
+// default values
+const (
+	defaultModel          = "gpt-4o-mini"
+	defaultMaxRetries     = 3
+	defaultRetryDelay     = time.Second
+	defaultMaxRetryDelay  = time.Minute
+	defaultConcurrency    = 4
+	defaultMaxTokens      = 64
+	defaultPromptTemplate = `Determine the sentiment of the mentions of a word in the contexts below.
+Contexts are separated by ";".
+
+Contexts: {{.Context}}`
+)
+
+// Config OpenAI-compatible client configuration
+type Config struct {
+	// URL is the base url of the server, e.g. https://api.openai.com
+	URL     string        `mapstructure:"url"`
+	Token   string        `mapstructure:"token"`
+	Model   string        `mapstructure:"model"`
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// PromptTemplate is text/template of the user message, context is available as {{.Context}}
+	PromptTemplate string  `mapstructure:"prompt_template"`
+	Temperature    float64 `mapstructure:"temperature"`
+	MaxTokens      int     `mapstructure:"max_tokens"`
+
+	// MaxRetries limits retries of rate-limited and failed requests, Retry-After header is honored
+	MaxRetries int `mapstructure:"max_retries"`
+
+	// TokensPerMinute limits tokens spent per minute, no limit if zero, retries are counted against it
+	TokensPerMinute int `mapstructure:"tokens_per_minute"`
+
+	// Concurrency limits concurrent requests of batch analysis
+	Concurrency int `mapstructure:"concurrency"`
+}
+
+// fixConfig sets default values for the Config if they are not provided
+func fixConfig(cfg *Config) {
+	if cfg.Model == "" {
+		cfg.Model = defaultModel
+	}
+
+	if cfg.PromptTemplate == "" {
+		cfg.PromptTemplate = defaultPromptTemplate
+	}
+
+	if cfg.MaxTokens <= 0 {
+		cfg.MaxTokens = defaultMaxTokens
+	}
+
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+}
@@ -0,0 +1,339 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/pkg/httpclient"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	chatCompletionsPath = "/v1/chat/completions"
+
+	systemPrompt = `You are a sentiment analysis service. Context contains mentions of the token separated by ";". ` +
+		`Answer only with JSON object with fields: "sentiment" - -1 for negative, 0 for neutral and 1 for positive ` +
+		`sentiment, "score" - sentiment score from -1 to 1, "confidence" - confidence of the answer from 0 to 1, ` +
+		`"mentions" - numbers of positive, neutral and negative mentions.`
+
+	// charsPerToken is used to estimate tokens of the request before it is sent
+	charsPerToken = 4
+)
+
+// sentimentSchema is json schema of the model answer, same as SentimentAnalysisResponse
+var sentimentSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"sentiment": map[string]any{
+			"type": "integer",
+			"enum": []int{-1, 0, 1},
+		},
+		"score": map[string]any{
+			"type": "number",
+		},
+		"confidence": map[string]any{
+			"type": "number",
+		},
+		"mentions": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"positive": map[string]any{"type": "integer"},
+				"neutral":  map[string]any{"type": "integer"},
+				"negative": map[string]any{"type": "integer"},
+			},
+			"required":             []string{"positive", "neutral", "negative"},
+			"additionalProperties": false,
+		},
+	},
+	"required":             []string{"sentiment", "score", "confidence", "mentions"},
+	"additionalProperties": false,
+}
+
+// chatMessage message of chat completions request
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// jsonSchemaFormat json schema of the response format
+type jsonSchemaFormat struct {
+	Name   string         `json:"name"`
+	Strict bool           `json:"strict"`
+	Schema map[string]any `json:"schema"`
+}
+
+// responseFormat format of the model answer
+type responseFormat struct {
+	Type       string           `json:"type"`
+	JSONSchema jsonSchemaFormat `json:"json_schema"`
+}
+
+// chatCompletionsRequest request of chat completions
+type chatCompletionsRequest struct {
+	Model          string         `json:"model"`
+	Messages       []chatMessage  `json:"messages"`
+	Temperature    float64        `json:"temperature"`
+	MaxTokens      int            `json:"max_tokens"`
+	ResponseFormat responseFormat `json:"response_format"`
+}
+
+// chatCompletionsResponse response of chat completions
+type chatCompletionsResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// promptData data available in prompt template
+type promptData struct {
+	Context string
+}
+
+// Client sentiment analysis client of OpenAI-compatible chat completions server
+type Client struct {
+	client *http.Client
+	cfg    Config
+	prompt *template.Template
+	budget *budget
+}
+
+// NewClient create new OpenAI-compatible client
+func NewClient(cfg Config) (*Client, error) {
+	fixConfig(&cfg)
+
+	prompt, err := template.New("prompt").Parse(cfg.PromptTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+
+	return &Client{
+		client: httpclient.DefaultClient(cfg.Timeout),
+		cfg:    cfg,
+		prompt: prompt,
+		budget: newBudget(cfg.TokensPerMinute),
+	}, nil
+}
+
+// SentimentAnalysis perform sentiment analysis
+func (c *Client) SentimentAnalysis(
+	ctx context.Context,
+	req *llm.SentimentAnalysisRequest,
+) (*llm.SentimentAnalysisResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("wrong request: %w", err)
+	}
+
+	var prompt strings.Builder
+	if err := c.prompt.Execute(&prompt, promptData{Context: req.Context}); err != nil {
+		return nil, fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	chatReq := &chatCompletionsRequest{
+		Model: c.cfg.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt.String()},
+		},
+		Temperature: c.cfg.Temperature,
+		MaxTokens:   c.cfg.MaxTokens,
+		ResponseFormat: responseFormat{
+			Type: "json_schema",
+			JSONSchema: jsonSchemaFormat{
+				Name:   "sentiment",
+				Strict: true,
+				Schema: sentimentSchema,
+			},
+		},
+	}
+
+	// estimated tokens of the request, they are corrected with actual usage after response
+	reserved := (len(systemPrompt)+prompt.Len())/charsPerToken + c.cfg.MaxTokens
+
+	chatResp, err := c.chatCompletions(ctx, chatReq, reserved)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return nil, errors.New("got no choices in response")
+	}
+
+	var resp llm.SentimentAnalysisResponse
+	if err := json.Unmarshal([]byte(chatResp.Choices[0].Message.Content), &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal model answer: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// BatchSentimentAnalysis perform sentiment analysis of many token contexts with concurrent requests,
+// errors of single items are returned within results
+func (c *Client) BatchSentimentAnalysis(
+	ctx context.Context,
+	req *llm.BatchSentimentAnalysisRequest,
+) (*llm.BatchSentimentAnalysisResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("wrong request: %w", err)
+	}
+
+	valid, invalid := req.SplitInvalid()
+
+	var (
+		results = make([]llm.BatchSentimentResult, len(valid))
+		g       errgroup.Group
+	)
+
+	g.SetLimit(c.cfg.Concurrency)
+
+	for i, item := range valid {
+		g.Go(func() error {
+			resp, err := c.SentimentAnalysis(ctx, &llm.SentimentAnalysisRequest{
+				Context: item.Context,
+			})
+			if err != nil {
+				results[i] = llm.NewBatchSentimentError(item.Token, err)
+				return nil
+			}
+
+			results[i] = llm.NewBatchSentimentResult(item.Token, resp) // every goroutine writes only its own item
+
+			return nil
+		})
+	}
+
+	_ = g.Wait() // errors are returned within results
+
+	return &llm.BatchSentimentAnalysisResponse{Results: append(invalid, results...)}, nil
+}
+
+// chatCompletions sends chat completions request, rate-limited and failed requests are retried,
+// every attempt reserves estimated tokens from the budget
+func (c *Client) chatCompletions(
+	ctx context.Context,
+	req *chatCompletionsRequest,
+	reserved int,
+) (*chatCompletionsResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.budget.wait(ctx, reserved); err != nil {
+			return nil, fmt.Errorf("failed to wait for token budget: %w", err)
+		}
+
+		resp, retryAfter, err := c.makeRequest(ctx, body)
+		if err == nil {
+			c.budget.adjust(reserved, resp.Usage.TotalTokens)
+			return resp, nil
+		}
+
+		// rejected request is not charged, but rate-limited and failed ones keep their reservation,
+		// so retries slow down instead of exceeding the budget
+		if retryAfter < 0 {
+			c.budget.adjust(reserved, 0)
+			return nil, err
+		}
+
+		if attempt >= c.cfg.MaxRetries {
+			return nil, err
+		}
+
+		// use exponential backoff if server did not tell when to retry
+		if retryAfter == 0 {
+			retryAfter = min(defaultRetryDelay<<attempt, defaultMaxRetryDelay)
+		}
+
+		timer := time.NewTimer(retryAfter)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// makeRequest sends a single chat completions request, returns delay before retry,
+// negative delay means that request should not be retried and zero means no hint from server
+func (c *Client) makeRequest(ctx context.Context, body []byte) (*chatCompletionsResponse, time.Duration, error) {
+	url := strings.TrimSuffix(c.cfg.URL, "/") + chatCompletionsPath
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, -1, err
+		}
+
+		return nil, 0, fmt.Errorf("failed to send request to %s: %w", chatCompletionsPath, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		statusErr := &llm.StatusError{
+			Path: chatCompletionsPath,
+			Code: resp.StatusCode,
+			Body: string(respBody),
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+			return nil, -1, statusErr
+		}
+
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")), statusErr
+	}
+
+	var chatResp chatCompletionsResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return nil, -1, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return &chatResp, 0, nil
+}
+
+// parseRetryAfter parses Retry-After header in seconds or http date format, returns zero if not set
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,302 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+)
+
+const answer = `{"sentiment":1,"score":0.8,"confidence":0.9,"mentions":{"positive":2,"neutral":1,"negative":0}}`
+
+// stubServer stand-in of chat completions server, responses are taken in order, the last one is repeated
+type stubServer struct {
+	calls     atomic.Int64
+	responses []func(w http.ResponseWriter, req *chatCompletionsRequest)
+}
+
+func (s *stubServer) start(t *testing.T, cfg Config) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != chatCompletionsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var req chatCompletionsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		call := int(s.calls.Add(1)) - 1
+		s.responses[min(call, len(s.responses)-1)](w, &req)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg.URL = srv.URL
+	cfg.Timeout = time.Second
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+// respond writes chat completions response with the model answer
+func respond(content string, totalTokens int) func(w http.ResponseWriter, req *chatCompletionsRequest) {
+	return func(w http.ResponseWriter, _ *chatCompletionsRequest) {
+		var resp chatCompletionsResponse
+		resp.Choices = append(resp.Choices, struct {
+			Message chatMessage `json:"message"`
+		}{Message: chatMessage{Role: "assistant", Content: content}})
+		resp.Usage.TotalTokens = totalTokens
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// fail writes error response with optional Retry-After header
+func fail(code int, retryAfter string) func(w http.ResponseWriter, req *chatCompletionsRequest) {
+	return func(w http.ResponseWriter, _ *chatCompletionsRequest) {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+
+		w.WriteHeader(code)
+	}
+}
+
+func analyze(ctx context.Context, client *Client) (*llm.SentimentAnalysisResponse, error) {
+	return client.SentimentAnalysis(ctx, &llm.SentimentAnalysisRequest{Context: "good phone; the phone is fine"})
+}
+
+func TestSentimentAnalysisStructuredOutput(t *testing.T) {
+	srv := &stubServer{}
+	srv.responses = append(srv.responses, func(w http.ResponseWriter, req *chatCompletionsRequest) {
+		format := req.ResponseFormat
+		if format.Type != "json_schema" || !format.JSONSchema.Strict || format.JSONSchema.Name != "sentiment" {
+			t.Errorf("unexpected response format: %+v", format)
+		}
+
+		// schema must survive json encoding, so the server gets the required fields
+		required, _ := format.JSONSchema.Schema["required"].([]any)
+		if len(required) != 4 {
+			t.Errorf("expected 4 required fields in schema, got %v", format.JSONSchema.Schema["required"])
+		}
+
+		respond(answer, 10)(w, req)
+	})
+
+	resp, err := analyze(context.Background(), srv.start(t, Config{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Sentiment != 1 || resp.Score == nil || *resp.Score != 0.8 ||
+		resp.Confidence == nil || *resp.Confidence != 0.9 || resp.Mentions == nil {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	if *resp.Mentions != (llm.SentimentMentions{Positive: 2, Neutral: 1}) {
+		t.Fatalf("unexpected mentions: %+v", *resp.Mentions)
+	}
+}
+
+func TestSentimentAnalysisMalformedAnswer(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond func(w http.ResponseWriter, req *chatCompletionsRequest)
+	}{
+		{name: "not json", respond: respond("positive", 10)},
+		{name: "wrong type", respond: respond(`{"sentiment":"positive"}`, 10)},
+		{name: "no choices", respond: func(w http.ResponseWriter, _ *chatCompletionsRequest) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"choices":[]}`))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){tt.respond}}
+
+			if _, err := analyze(context.Background(), srv.start(t, Config{})); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestSentimentAnalysisRetryAfter(t *testing.T) {
+	srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){
+		fail(http.StatusTooManyRequests, "1"),
+		respond(answer, 10),
+	}}
+	client := srv.start(t, Config{MaxRetries: 1})
+
+	start := time.Now()
+
+	if _, err := analyze(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected retry after 1s, got %s", elapsed)
+	}
+
+	if calls := srv.calls.Load(); calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestSentimentAnalysisNoRetry(t *testing.T) {
+	srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){
+		fail(http.StatusBadRequest, "1"),
+	}}
+
+	_, err := analyze(context.Background(), srv.start(t, Config{MaxRetries: 3}))
+
+	var statusErr *llm.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status error with code 400, got %v", err)
+	}
+
+	if calls := srv.calls.Load(); calls != 1 {
+		t.Fatalf("expected 1 request, got %d", calls)
+	}
+}
+
+func TestSentimentAnalysisRetriesExhausted(t *testing.T) {
+	srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){
+		fail(http.StatusServiceUnavailable, ""),
+	}}
+
+	_, err := analyze(context.Background(), srv.start(t, Config{MaxRetries: 1}))
+
+	var statusErr *llm.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status error with code 503, got %v", err)
+	}
+
+	if calls := srv.calls.Load(); calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestSentimentAnalysisRetryCanceled(t *testing.T) {
+	srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){
+		fail(http.StatusServiceUnavailable, "10"),
+	}}
+
+	// retry delay is longer than the context, so the client gives up while waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := analyze(ctx, srv.start(t, Config{MaxRetries: 3})); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	if calls := srv.calls.Load(); calls != 1 {
+		t.Fatalf("expected 1 request, got %d", calls)
+	}
+}
+
+func TestSentimentAnalysisBudgetExhausted(t *testing.T) {
+	// single request reserves about 1100 tokens, so only one of them fits into the budget
+	cfg := Config{MaxTokens: 1000, TokensPerMinute: 1500}
+
+	t.Run("actual usage", func(t *testing.T) {
+		srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){
+			respond(answer, 1400),
+		}}
+		client := srv.start(t, cfg)
+
+		if _, err := analyze(context.Background(), client); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if client.budget.used != 1400 {
+			t.Fatalf("expected budget to be corrected with actual usage, got %d", client.budget.used)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if _, err := analyze(ctx, client); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected to wait for budget, got %v", err)
+		}
+
+		if calls := srv.calls.Load(); calls != 1 {
+			t.Fatalf("expected 1 request, got %d", calls)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){
+			fail(http.StatusServiceUnavailable, "1"),
+		}}
+
+		cfg := cfg
+		cfg.MaxRetries = 1
+
+		client := srv.start(t, cfg)
+
+		// the failed attempt keeps its reservation, so the retry waits for the next minute
+		ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+		defer cancel()
+
+		if _, err := analyze(ctx, client); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected retry to wait for budget, got %v", err)
+		}
+
+		if calls := srv.calls.Load(); calls != 1 {
+			t.Fatalf("expected retry not to be sent, got %d requests", calls)
+		}
+	})
+
+	t.Run("rejected attempt", func(t *testing.T) {
+		srv := &stubServer{responses: []func(http.ResponseWriter, *chatCompletionsRequest){
+			fail(http.StatusBadRequest, ""),
+		}}
+		client := srv.start(t, cfg)
+
+		if _, err := analyze(context.Background(), client); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if client.budget.used != 0 {
+			t.Fatalf("expected rejected request to release its reservation, got %d", client.budget.used)
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{value: "", min: 0, max: 0},
+		{value: "5", min: 5 * time.Second, max: 5 * time.Second},
+		{value: "-5", min: 0, max: 0},
+		{value: "soon", min: 0, max: 0},
+		{value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute},
+		{value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got < tt.min || got > tt.max {
+			t.Errorf("parseRetryAfter(%q) = %s, expected between %s and %s", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
@@ -33,6 +33,15 @@ func (k *Kafka) ProduceJSON(msg Message) error {
 	return nil
 }
 
+// Produce sends an already prepared message (e.g. with custom headers) to Kafka
+func (k *Kafka) Produce(msg *sarama.ProducerMessage) error {
+	if _, _, err := k.p.SendMessage(msg); err != nil {
+		return fmt.Errorf("failed to send message to Kafka: %w", err)
+	}
+
+	return nil
+}
+
 // getJSON processes the JSON value of the message
 func (k *Kafka) getJSON(value any) (sarama.StringEncoder, error) {
 	bytes, err := json.Marshal(value)
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer"
+)
+
+// CoOccurrenceMetric counts pairs of tokens co-occurring within the context window,
+// filtered tokens and phrases are not counted.
+type CoOccurrenceMetric struct {
+	mu    sync.RWMutex
+	pairs map[string]map[string]int64
+}
+
+// NewCoOccurrenceMetric creates a new instance of CoOccurrenceMetric.
+func NewCoOccurrenceMetric() *CoOccurrenceMetric {
+	return &CoOccurrenceMetric{
+		pairs: make(map[string]map[string]int64),
+	}
+}
+
+// Collect implements counting tokens from the context of a given token.
+func (m *CoOccurrenceMetric) Collect(token *tokenizer.Token) error {
+	if !isCoOccurrenceToken(token) {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	contextTokens := token.ContextTokens()
+	for i := range contextTokens {
+		related := &contextTokens[i]
+		if !isCoOccurrenceToken(related) {
+			continue
+		}
+
+		// context includes the token itself, repeated token is not related to itself
+		target := related.SharedTarget()
+		if target == token.Target {
+			continue
+		}
+
+		if _, ok := m.pairs[token.Target]; !ok {
+			m.pairs[token.Target] = make(map[string]int64)
+		}
+		m.pairs[token.Target][target]++
+	}
+
+	return nil
+}
+
+// Get implements retrieving counts of tokens co-occurring with a given token as map[string]int64.
+func (m *CoOccurrenceMetric) Get(token string) (any, bool) {
+	m.mu.RLock()
+	val, ok := m.pairs[token]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	return maps.Clone(val), true
+}
+
+// isCoOccurrenceToken checks if the token is counted in pairs
+func isCoOccurrenceToken(token *tokenizer.Token) bool {
+	return token.Target != "" && !token.IsFiltered() && token.Kind() != tokenizer.KindPhrase
+}
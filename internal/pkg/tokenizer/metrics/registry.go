@@ -1,11 +1,35 @@
 package metrics
 
+import "fmt"
+
 // Indices of available metrics
 const (
 	InterestMetricIndex = iota
 )
 
+// Names of available metrics, used in pipeline config
+const (
+	InterestMetricName     = "interest"
+	CoOccurrenceMetricName = "cooccurrence"
+)
+
 // Registry holds all available metrics for token processing.
 var Registry = []Metric{
 	NewInterestMetric(),
 }
+
+// factories maps metric name to its constructor.
+var factories = map[string]func() Metric{
+	InterestMetricName:     func() Metric { return NewInterestMetric() },
+	CoOccurrenceMetricName: func() Metric { return NewCoOccurrenceMetric() },
+}
+
+// New creates a new metric by its name.
+func New(name string) (Metric, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown metric %q", name)
+	}
+
+	return factory(), nil
+}
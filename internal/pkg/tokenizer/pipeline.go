@@ -1,22 +1,205 @@
 package tokenizer
 
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// DefaultStreamChunkSize is the default number of tokens in a chunk of streaming pipeline
+const DefaultStreamChunkSize = 256
+
+// StreamConfig represents config of streaming pipeline, non-positive values are replaced with defaults:
+// DefaultStreamChunkSize tokens in a chunk and GOMAXPROCS workers per stage
+type StreamConfig struct {
+	ChunkSize int
+	Workers   int
+}
+
+// streamableStage is implemented by stages which may process every token independently of other tokens
+type streamableStage interface {
+	Streamable() bool
+}
+
 // Pipeline represents a sequence of processing stages for tokens.
 type Pipeline struct {
 	initStage PipelineStage
+	stages    []PipelineStage
+	names     []string
+	stream    *StreamConfig
 }
 
-// Run processes the input tokens through the pipeline and returns the processed tokens.
-func (p *Pipeline) Run(tokens []Token) []Token {
+// Run processes the input tokens through the pipeline and returns the processed tokens,
+// error is returned if a stage aborted the run.
+func (p *Pipeline) Run(tokens []Token) ([]Token, error) {
+	if p.stream != nil {
+		return p.runStream(tokens)
+	}
+
 	if p.initStage == nil {
-		return tokens
+		return tokens, nil
+	}
+
+	tokens = p.initStage.Execute(tokens)
+
+	if err := p.err(p.stages, p.names); err != nil {
+		return nil, err
 	}
 
-	return p.initStage.Execute(tokens)
+	return tokens, nil
+}
+
+// runStream executes stages one by one, consecutive streamable stages are executed on chunks of tokens
+// passed through channels, so every stage processes chunks concurrently and next stage starts before
+// previous one finishes the message. Stages processing the whole message wait for all chunks.
+func (p *Pipeline) runStream(tokens []Token) ([]Token, error) {
+	for i := 0; i < len(p.stages); {
+		if !isStreamable(p.stages[i]) {
+			tokens = p.stages[i].Execute(tokens)
+
+			if err := p.err(p.stages[i:i+1], p.names[i:i+1]); err != nil {
+				return nil, err
+			}
+
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < len(p.stages) && isStreamable(p.stages[j]) {
+			j++
+		}
+
+		p.streamChunks(tokens, p.stages[i:j])
+
+		if err := p.err(p.stages[i:j], p.names[i:j]); err != nil {
+			return nil, err
+		}
+
+		i = j
+	}
+
+	return tokens, nil
+}
+
+// streamChunks executes streamable stages on chunks of tokens, tokens are processed in place,
+// so order of tokens is kept. Chunks are not passed further once any stage aborted the run.
+func (p *Pipeline) streamChunks(tokens []Token, stages []PipelineStage) {
+	// goroutines are not worth it for a single chunk
+	if len(tokens) <= p.stream.ChunkSize {
+		for _, stage := range stages {
+			stage.Execute(tokens)
+
+			if stage.Err() != nil {
+				return
+			}
+		}
+
+		return
+	}
+
+	var (
+		aborted   = make(chan struct{})
+		abortOnce sync.Once
+		chunks    = make(chan []Token)
+	)
+
+	go func() {
+		defer close(chunks)
+
+		for chunk := range slices.Chunk(tokens, p.stream.ChunkSize) {
+			select {
+			case chunks <- chunk:
+			case <-aborted:
+				return
+			}
+		}
+	}()
+
+	// output of every stage is input of the next one
+	in := chunks
+	for _, stage := range stages {
+		var (
+			wg  sync.WaitGroup
+			out = make(chan []Token)
+		)
+
+		for range p.stream.Workers {
+			wg.Add(1)
+
+			go func(in <-chan []Token) {
+				defer wg.Done()
+
+				for chunk := range in {
+					select {
+					case <-aborted:
+						continue // drain chunks sent before abort
+					default:
+					}
+
+					stage.Execute(chunk)
+
+					if stage.Err() != nil {
+						abortOnce.Do(func() { close(aborted) })
+						continue
+					}
+
+					out <- chunk
+				}
+			}(in)
+		}
+
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		in = out
+	}
+
+	// wait for the last stage
+	for range in {
+	}
+}
+
+// err returns the error of the first stage which aborted the run
+func (p *Pipeline) err(stages []PipelineStage, names []string) error {
+	for i, stage := range stages {
+		if err := stage.Err(); err != nil {
+			return fmt.Errorf("stage %s aborted pipeline: %w", names[i], err)
+		}
+	}
+
+	return nil
+}
+
+// isStreamable checks if the stage can be executed on chunks of tokens
+func isStreamable(stage PipelineStage) bool {
+	s, ok := stage.(streamableStage)
+
+	return ok && s.Streamable()
+}
+
+// Stats returns statistics of the pipeline stages in order of execution.
+func (p *Pipeline) Stats() []StageStats {
+	stats := make([]StageStats, 0, len(p.stages))
+
+	for i, stage := range p.stages {
+		stageStats := stage.Stats()
+		stageStats.Name = p.names[i]
+
+		stats = append(stats, stageStats)
+	}
+
+	return stats
 }
 
 // PipelineBuilder helps in constructing a Pipeline by adding stages.
 type PipelineBuilder struct {
 	stages []PipelineStage
+	names  []string
+	stream *StreamConfig
 }
 
 // NewPipelineBuilder creates a new instance of PipelineBuilder.
@@ -24,19 +207,55 @@ func NewPipelineBuilder() *PipelineBuilder {
 	return &PipelineBuilder{}
 }
 
-// AddStages adds multiple stages to the pipeline.
+// AddStages adds multiple stages to the pipeline, stages are named by their types in statistics.
 func (b *PipelineBuilder) AddStages(stages ...PipelineStage) *PipelineBuilder {
-	b.stages = append(b.stages, stages...)
+	for _, stage := range stages {
+		b.AddNamedStage(fmt.Sprintf("%T", stage), stage)
+	}
+
+	return b
+}
+
+// AddNamedStage adds the stage to the pipeline, the name is used in statistics.
+func (b *PipelineBuilder) AddNamedStage(name string, stage PipelineStage) *PipelineBuilder {
+	b.stages = append(b.stages, stage)
+	b.names = append(b.names, name)
+
+	return b
+}
+
+// Stream makes the pipeline streaming, tokens with lazy context (see GetLazyTokens) are recommended for it.
+func (b *PipelineBuilder) Stream(cfg StreamConfig) *PipelineBuilder {
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = DefaultStreamChunkSize
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	b.stream = &cfg
 
 	return b
 }
 
-// Build constructs the Pipeline with the added stages.
+// Build constructs the Pipeline with the added stages, stages of streaming pipeline are not linked,
+// because they are executed one by one.
 func (b *PipelineBuilder) Build() *Pipeline {
+	if b.stream != nil {
+		return &Pipeline{
+			stages: b.stages,
+			names:  b.names,
+			stream: b.stream,
+		}
+	}
+
 	initStage := connectPipelineStages(b.stages...)
 
 	return &Pipeline{
 		initStage: initStage,
+		stages:    b.stages,
+		names:     b.names,
 	}
 }
 
@@ -0,0 +1,90 @@
+package tokenizer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/stages"
+)
+
+const sampleMessage = `Новый смартфон получил процессор Snapdragon 8 Gen 3, экран 120 Гц и батарею на 5000 мА·ч. ` +
+	`Продажи стартуют в октябре, цена в России составит около 90 тысяч рублей. ` +
+	`The new smartphone ships with Wi-Fi 7, USB-C and Android 15, reviewers praise the camera and battery life. `
+
+// largeMessage is a message of 23 thousand words, so it is split into many chunks of the streaming pipeline
+var largeMessage = strings.Repeat(sampleMessage, 500)
+
+// newPipelineFactory creates factory of the default pipeline, streaming or eager one
+func newPipelineFactory(tb testing.TB, streaming bool) *stages.PipelineFactory {
+	tb.Helper()
+
+	cfg := stages.DefaultPipelineConfig()
+	cfg.Streaming = stages.StreamingConfig{Enabled: streaming}
+
+	factory, err := stages.NewPipelineFactory(cfg)
+	if err != nil {
+		tb.Fatalf("failed to create pipeline factory: %v", err)
+	}
+
+	return factory
+}
+
+// runPipeline tokenizes the message and runs it through a new pipeline of the factory
+func runPipeline(tb testing.TB, factory *stages.PipelineFactory, text string) []tokenizer.Token {
+	tb.Helper()
+
+	pipeline, _ := factory.Build()
+
+	tokens, err := pipeline.Run(factory.Tokens(text))
+	if err != nil {
+		tb.Fatalf("failed to run pipeline: %v", err)
+	}
+
+	return tokens
+}
+
+func TestStreamingPipelineMatchesEager(t *testing.T) {
+	eager := runPipeline(t, newPipelineFactory(t, false), largeMessage)
+	stream := runPipeline(t, newPipelineFactory(t, true), largeMessage)
+
+	if len(eager) != len(stream) {
+		t.Fatalf("expected %d tokens, got %d", len(eager), len(stream))
+	}
+
+	for i := range eager {
+		if eager[i].Target != stream[i].Target || eager[i].IsFiltered() != stream[i].IsFiltered() {
+			t.Fatalf("token %d: expected %q, got %q", i, eager[i].Target, stream[i].Target)
+		}
+
+		eagerContext, streamContext := eager[i].ContextTokens(), stream[i].ContextTokens()
+		if len(eagerContext) != len(streamContext) {
+			t.Fatalf("token %d: expected context of %d tokens, got %d", i, len(eagerContext), len(streamContext))
+		}
+
+		for j := range eagerContext {
+			if eagerContext[j].Surface != streamContext[j].Surface {
+				t.Fatalf("token %d: expected context %q, got %q", i, eagerContext[j].Surface, streamContext[j].Surface)
+			}
+		}
+	}
+}
+
+func benchmarkPipeline(b *testing.B, streaming bool) {
+	factory := newPipelineFactory(b, streaming)
+
+	b.SetBytes(int64(len(largeMessage)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		runPipeline(b, factory, largeMessage)
+	}
+}
+
+func BenchmarkPipelineEager(b *testing.B) {
+	benchmarkPipeline(b, false)
+}
+
+func BenchmarkPipelineStreaming(b *testing.B) {
+	benchmarkPipeline(b, true)
+}
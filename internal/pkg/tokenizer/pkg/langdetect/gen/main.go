@@ -0,0 +1,234 @@
+// Command gen builds n-gram profiles of langdetect from translations of the GNU gettext catalogs
+// (/usr/share/locale/<locale>/LC_MESSAGES/*.mo of Debian-based systems): translated messages of the locale
+// form the corpus of the language and original messages form the English corpus.
+//
+// Run it from the repository root:
+//
+//	go run ./internal/pkg/tokenizer/pkg/langdetect/gen
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/langdetect"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
+// moMagic is the magic number of gettext .mo files
+const moMagic = 0x950412de
+
+// corpus describes where the corpus of the language is taken from
+type corpus struct {
+	language textutil.Language
+	locale   string
+	// original messages are taken instead of translations
+	original bool
+}
+
+var corpora = []corpus{
+	{language: textutil.Russian, locale: "ru"},
+	{language: textutil.Ukrainian, locale: "uk"},
+	{language: textutil.Kazakh, locale: "kk"},
+	{language: textutil.German, locale: "de"},
+	{language: textutil.English, locale: "de", original: true},
+}
+
+// placeholders matches printf directives, named placeholders and markup which are not words of the language
+var placeholders = regexp.MustCompile(`%(\d+\$)?[-+ #0']*(\d+|\*)?(\.(\d+|\*))?(hh|h|ll|l|L|q|j|z|t|I)*[a-zA-Z%]|` +
+	`%\([a-z_]+\)[a-z]|\$\{?[A-Za-z_]+\}?|\{[^}]*\}|<[^>]*>|&[a-z]+;`)
+
+func main() {
+	var (
+		localeDir  = flag.String("locales", "/usr/share/locale", "directory of gettext catalogs")
+		profileDir = flag.String("out", "internal/pkg/tokenizer/pkg/langdetect/profiles", "directory of profiles")
+	)
+
+	flag.Parse()
+
+	for _, c := range corpora {
+		if err := generate(c, *localeDir, *profileDir); err != nil {
+			log.Fatalf("failed to generate profile of %s: %v", c.language, err)
+		}
+	}
+}
+
+// generate builds profile of the language from its corpus
+func generate(c corpus, localeDir string, profileDir string) error {
+	catalogs, err := filepath.Glob(filepath.Join(localeDir, c.locale, "LC_MESSAGES", "*.mo"))
+	if err != nil {
+		return err
+	}
+
+	// iso-codes catalogs are lists of names, mostly transliterated
+	catalogs = slices.DeleteFunc(catalogs, func(path string) bool {
+		return strings.HasPrefix(filepath.Base(path), "iso_")
+	})
+
+	if len(catalogs) == 0 {
+		return fmt.Errorf("no catalogs of locale %s in %s", c.locale, localeDir)
+	}
+
+	var (
+		text  strings.Builder
+		names = make([]string, 0, len(catalogs))
+		words int
+	)
+
+	for _, path := range catalogs {
+		messages, err := readCatalog(path, c.original)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		for _, msg := range messages {
+			for _, word := range cleanWords(msg, c.language.Script()) {
+				text.WriteString(word)
+				text.WriteByte(' ')
+				words++
+			}
+		}
+
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".mo"))
+	}
+
+	file, err := os.Create(filepath.Join(profileDir, fmt.Sprintf("%s.txt", c.language)))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+
+	source := "translations"
+	if c.original {
+		source = "original messages"
+	}
+
+	fmt.Fprintf(w, "# n-gram profile of %s generated by langdetect/gen, do not edit\n", c.language)
+	fmt.Fprintf(w, "# corpus: %d words of %s of gettext catalogs of locale %s:\n", words, source, c.locale)
+
+	for line := range slices.Chunk(names, 8) {
+		fmt.Fprintf(w, "# %s\n", strings.Join(line, ", "))
+	}
+
+	if err := langdetect.WriteProfile(w, text.String()); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// cleanWords returns words of the message written in the script, placeholders, options, paths
+// and acronyms are skipped, because they are the same in every language
+func cleanWords(msg string, script *unicode.RangeTable) []string {
+	msg = placeholders.ReplaceAllString(msg, " ")
+
+	var words []string
+
+	for _, field := range strings.Fields(msg) {
+		if strings.HasPrefix(field, "-") || strings.ContainsAny(field, `/\=@_|`) {
+			continue
+		}
+
+		for _, word := range strings.FieldsFunc(field, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			if isWordOf(word, script) {
+				words = append(words, word)
+			}
+		}
+	}
+
+	return words
+}
+
+// isWordOf checks if every letter of the word is of the script and the word is not an acronym
+func isWordOf(word string, script *unicode.RangeTable) bool {
+	var upper int
+
+	for _, r := range word {
+		if !unicode.In(r, script) {
+			return false
+		}
+
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+
+	return upper < 2
+}
+
+// readCatalog reads messages of the gettext .mo file, translations or original messages,
+// plural forms are separated by zero byte and the header is skipped
+func readCatalog(path string, original bool) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 20 {
+		return nil, errors.New("file is too short")
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(data) != moMagic {
+		order = binary.BigEndian
+		if order.Uint32(data) != moMagic {
+			return nil, errors.New("not a .mo file")
+		}
+	}
+
+	var (
+		count        = int(order.Uint32(data[8:]))
+		originals    = int(order.Uint32(data[12:]))
+		translations = int(order.Uint32(data[16:]))
+		messages     = make([]string, 0, count)
+	)
+
+	// readString reads the i-th string of the table
+	readString := func(table int, i int) (string, error) {
+		entry := table + 8*i
+		if entry+8 > len(data) {
+			return "", errors.New("string table is out of file")
+		}
+
+		length, offset := int(order.Uint32(data[entry:])), int(order.Uint32(data[entry+4:]))
+		if offset+length > len(data) {
+			return "", errors.New("string is out of file")
+		}
+
+		return string(data[offset : offset+length]), nil
+	}
+
+	for i := range count {
+		id, err := readString(originals, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if id == "" {
+			continue // header
+		}
+
+		msg := id
+		if !original {
+			if msg, err = readString(translations, i); err != nil {
+				return nil, err
+			}
+		}
+
+		messages = append(messages, strings.Split(msg, "\x00")...)
+	}
+
+	return messages, nil
+}
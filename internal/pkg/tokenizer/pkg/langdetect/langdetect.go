@@ -0,0 +1,251 @@
+package langdetect
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
+const (
+	// profileSize is the number of the most frequent n-grams kept in a profile
+	profileSize = 1000
+	// maxNGram is the max length of character n-grams
+	maxNGram = 3
+)
+
+// profiles are generated from a text corpus by gen, see WriteProfile for the format
+//
+//go:embed profiles/*.txt
+var profileFiles embed.FS
+
+// supportedLanguages are the languages having profiles
+var supportedLanguages = []textutil.Language{
+	textutil.English,
+	textutil.Russian,
+	textutil.Ukrainian,
+	textutil.Kazakh,
+	textutil.German,
+}
+
+// profiles maps language to its embedded n-gram profile
+var profiles = make(map[textutil.Language]profile)
+
+// init loads profiles of all supported languages
+func init() {
+	for _, language := range supportedLanguages {
+		data, err := profileFiles.ReadFile(fmt.Sprintf("profiles/%s.txt", language))
+		if err != nil {
+			panic(fmt.Sprintf("no profile for language %s: %v", language, err))
+		}
+
+		p, err := parseProfile(data)
+		if err != nil {
+			panic(fmt.Sprintf("invalid profile for language %s: %v", language, err))
+		}
+
+		profiles[language] = p
+	}
+}
+
+// profile maps n-gram to its rank, the most frequent n-gram has rank 0
+type profile map[string]int
+
+// newProfile creates profile of the most frequent character n-grams of the text
+func newProfile(text string) profile {
+	ngrams, _ := topNGrams(text)
+
+	p := make(profile, len(ngrams))
+	for rank, ngram := range ngrams {
+		p[ngram] = rank
+	}
+
+	return p
+}
+
+// parseProfile parses profile written by WriteProfile
+func parseProfile(data []byte) (profile, error) {
+	p := make(profile, profileSize)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ngram, _, _ := strings.Cut(line, "\t")
+		if _, ok := p[ngram]; ok {
+			return nil, fmt.Errorf("duplicate n-gram %q", ngram)
+		}
+
+		p[ngram] = len(p)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(p) == 0 {
+		return nil, errors.New("empty profile")
+	}
+
+	return p, nil
+}
+
+// WriteProfile writes profile of the text corpus: the most frequent character n-grams, one per line
+// in order of their rank with the number of occurrences separated by tab, "_" marks word boundaries
+func WriteProfile(w io.Writer, text string) error {
+	ngrams, counts := topNGrams(text)
+
+	for _, ngram := range ngrams {
+		if _, err := fmt.Fprintf(w, "%s\t%d\n", ngram, counts[ngram]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// topNGrams returns the most frequent character n-grams of the text in order of their rank and counts of n-grams
+func topNGrams(text string) ([]string, map[string]int) {
+	counts := make(map[string]int)
+
+	for _, word := range words(text) {
+		runes := []rune("_" + word + "_")
+
+		for n := 1; n <= maxNGram; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				counts[string(runes[i:i+n])]++
+			}
+		}
+	}
+
+	ngrams := make([]string, 0, len(counts))
+	for ngram := range counts {
+		ngrams = append(ngrams, ngram)
+	}
+
+	// sort by frequency, ties are sorted alphabetically to keep ranks stable
+	slices.SortFunc(ngrams, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), strings.Compare(a, b))
+	})
+
+	return ngrams[:min(len(ngrams), profileSize)], counts
+}
+
+// distance returns "out-of-place" distance between profiles, n-grams missing in the other profile
+// get the max penalty
+func (p profile) distance(other profile) int {
+	var dist int
+
+	for ngram, rank := range p {
+		otherRank, ok := other[ngram]
+		if !ok {
+			dist += profileSize
+			continue
+		}
+
+		dist += max(rank-otherRank, otherRank-rank)
+	}
+
+	return dist
+}
+
+// words splits the text into lowercased words of letters
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// Detector detects language of the text by its character n-grams (Cavnar-Trenkle method)
+type Detector struct {
+	languages []textutil.Language
+}
+
+// New creates a new Detector choosing between the given languages, all supported languages are used if none
+// are provided
+func New(languages ...textutil.Language) (*Detector, error) {
+	if len(languages) == 0 {
+		languages = slices.Sorted(maps.Keys(profiles))
+	}
+
+	for _, language := range languages {
+		if _, ok := profiles[language]; !ok {
+			return nil, fmt.Errorf("language detection is not supported for language %s", language)
+		}
+	}
+
+	return &Detector{languages: languages}, nil
+}
+
+// Detect returns the language of the text, only languages written in the prevailing script of the text
+// are compared, the default language is returned if the text has no letters of the detector languages
+func (d *Detector) Detect(text string) textutil.Language {
+	script := prevailingScript(text)
+	if script == nil {
+		return textutil.DefaultLanguage
+	}
+
+	candidates := make([]textutil.Language, 0, len(d.languages))
+	for _, language := range d.languages {
+		if language.Script() == script {
+			candidates = append(candidates, language)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return textutil.DetectLanguage(text)
+	case 1:
+		return candidates[0]
+	}
+
+	var (
+		textProfile = newProfile(text)
+		best        = candidates[0]
+		bestDist    = -1
+	)
+
+	for _, language := range candidates {
+		dist := textProfile.distance(profiles[language])
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = language, dist
+		}
+	}
+
+	return best
+}
+
+// prevailingScript returns the script of the most letters of the text, nil if text has no cyrillic or latin letters
+func prevailingScript(text string) *unicode.RangeTable {
+	var cyrillic, latin int
+
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Cyrillic):
+			cyrillic++
+		case unicode.In(r, unicode.Latin):
+			latin++
+		}
+	}
+
+	switch {
+	case cyrillic == 0 && latin == 0:
+		return nil
+	case cyrillic >= latin:
+		return unicode.Cyrillic
+	default:
+		return unicode.Latin
+	}
+}
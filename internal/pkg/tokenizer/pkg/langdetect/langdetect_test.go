@@ -0,0 +1,132 @@
+package langdetect
+
+import (
+	"testing"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
+// minAccuracy is the min share of correctly detected samples of every language
+const minAccuracy = 0.9
+
+// testSamples are short messages of the kind processor gets from scrapers, they are not a part of the profile corpus
+var testSamples = map[textutil.Language][]string{
+	textutil.Russian: {
+		"Вчера вечером в центре города прошел концерт, собравший несколько тысяч зрителей.",
+		"Цены на бензин снова выросли, водители жалуются на очереди на заправках.",
+		"Не могу найти зарядку для ноутбука, кто-нибудь видел её?",
+		"Новая версия приложения работает быстрее и почти не тратит батарею.",
+		"Школьники вернулись с каникул, а учителя готовятся к проверочным работам.",
+		"Сборная уверенно обыграла соперника и вышла в полуфинал турнира.",
+		"Подскажите хороший сервис, где можно недорого отремонтировать телефон.",
+		"Синоптики обещают на выходных сильный ветер и мокрый снег.",
+		"Банк снизил ставки по ипотеке, и спрос на квартиры заметно вырос.",
+		"Мы долго спорили, но в итоге решили поехать к морю на машине.",
+		"Всем спасибо за поддержку!",
+		"Где купить билеты?",
+	},
+	textutil.Ukrainian: {
+		"Вчора ввечері в центрі міста відбувся концерт, який зібрав кілька тисяч глядачів.",
+		"Ціни на пальне знову зросли, водії скаржаться на черги на заправках.",
+		"Не можу знайти зарядку для ноутбука, хтось її бачив?",
+		"Нова версія застосунку працює швидше і майже не витрачає батарею.",
+		"Школярі повернулися з канікул, а вчителі готуються до контрольних робіт.",
+		"Збірна впевнено обіграла суперника та вийшла до півфіналу турніру.",
+		"Підкажіть гарний сервіс, де можна недорого відремонтувати телефон.",
+		"Синоптики обіцяють на вихідних сильний вітер і мокрий сніг.",
+		"Банк знизив ставки за іпотекою, і попит на квартири помітно зріс.",
+		"Ми довго сперечалися, але зрештою вирішили поїхати до моря автівкою.",
+		"Дякую всім за підтримку!",
+		"Де купити квитки?",
+	},
+	textutil.Kazakh: {
+		"Кеше кешке қала орталығында бірнеше мың көрермен жиналған концерт өтті.",
+		"Жанармай бағасы тағы да өсті, жүргізушілер бекеттердегі кезекке шағымданады.",
+		"Ноутбуктың зарядтағышын таба алмай жүрмін, біреу көрді ме?",
+		"Қосымшаның жаңа нұсқасы жылдамырақ жұмыс істейді және батареяны аз жұмсайды.",
+		"Оқушылар демалыстан оралды, ал мұғалімдер бақылау жұмыстарына дайындалып жатыр.",
+		"Құрама команда қарсыласын сенімді жеңіп, турнирдің жартылай финалына шықты.",
+		"Телефонды арзан жөндейтін жақсы сервис ұсынып жіберіңіздерші.",
+		"Синоптиктер демалыс күндері қатты жел мен ылғалды қар болатынын айтады.",
+		"Банк ипотека бойынша мөлшерлемені төмендетті, пәтерге сұраныс айтарлықтай артты.",
+		"Біз ұзақ таластық, бірақ ақыры теңізге көлікпен баруды шештік.",
+		"Барлығыңызға қолдау үшін рахмет!",
+		"Билетті қайдан сатып алуға болады?",
+	},
+	textutil.English: {
+		"Last night a concert in the city centre drew several thousand people.",
+		"Fuel prices went up again and drivers are complaining about long queues.",
+		"I can't find my laptop charger, has anyone seen it?",
+		"The new version of the app is faster and barely drains the battery.",
+		"Students are back from the holidays and teachers are preparing the first tests.",
+		"The national team comfortably beat their rivals and reached the semifinal.",
+		"Can anyone recommend a cheap and reliable phone repair shop nearby?",
+		"Forecasters expect strong winds and wet snow over the weekend.",
+		"The bank cut mortgage rates and demand for apartments has noticeably grown.",
+		"We argued for a long time but finally decided to drive to the seaside.",
+		"Thanks everyone for the support!",
+		"Where can I buy tickets?",
+	},
+	textutil.German: {
+		"Gestern Abend fand im Stadtzentrum ein Konzert mit mehreren tausend Zuschauern statt.",
+		"Die Benzinpreise sind wieder gestiegen und die Autofahrer beschweren sich über lange Schlangen.",
+		"Ich finde das Ladegerät für meinen Laptop nicht, hat es jemand gesehen?",
+		"Die neue Version der App ist schneller und verbraucht kaum noch Akku.",
+		"Die Schüler sind aus den Ferien zurück und die Lehrer bereiten die ersten Klassenarbeiten vor.",
+		"Die Nationalmannschaft hat den Gegner souverän geschlagen und steht im Halbfinale.",
+		"Kann mir jemand eine günstige Werkstatt für Handyreparaturen empfehlen?",
+		"Die Meteorologen erwarten am Wochenende starken Wind und nassen Schnee.",
+		"Die Bank hat die Zinsen für Hypotheken gesenkt und die Nachfrage nach Wohnungen ist gestiegen.",
+		"Wir haben lange diskutiert und uns schließlich entschieden, mit dem Auto ans Meer zu fahren.",
+		"Danke an alle für die Unterstützung!",
+		"Wo kann man Tickets kaufen?",
+	},
+}
+
+func TestDetectAccuracy(t *testing.T) {
+	detector, err := New()
+	if err != nil {
+		t.Fatalf("failed to create detector: %v", err)
+	}
+
+	for language, texts := range testSamples {
+		var correct int
+
+		for _, text := range texts {
+			if got := detector.Detect(text); got == language {
+				correct++
+			} else {
+				t.Logf("%s: detected %s for %q", language, got, text)
+			}
+		}
+
+		accuracy := float64(correct) / float64(len(texts))
+		if accuracy < minAccuracy {
+			t.Errorf("%s: accuracy %.2f is less than %.2f", language, accuracy, minAccuracy)
+		}
+	}
+}
+
+func TestDetectCandidates(t *testing.T) {
+	detector, err := New(textutil.Russian, textutil.English)
+	if err != nil {
+		t.Fatalf("failed to create detector: %v", err)
+	}
+
+	// the only candidate of the script is returned without comparing profiles
+	if got := detector.Detect(testSamples[textutil.Ukrainian][0]); got != textutil.Russian {
+		t.Errorf("expected %s, got %s", textutil.Russian, got)
+	}
+
+	if got := detector.Detect("2024 — 15:30"); got != textutil.DefaultLanguage {
+		t.Errorf("expected default language for text without letters, got %s", got)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	for _, language := range supportedLanguages {
+		if size := len(profiles[language]); size != profileSize {
+			t.Errorf("%s: expected profile of %d n-grams, got %d", language, profileSize, size)
+		}
+	}
+}
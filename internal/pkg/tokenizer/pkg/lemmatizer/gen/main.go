@@ -0,0 +1,215 @@
+// Command gen builds the embedded Russian dictionary of lemmatizer from the OpenCorpora dictionary
+// in text format (dict.opcorpora.txt or dict.opcorpora.txt.bz2 from https://opencorpora.org/?page=downloads).
+// The full dictionary is too large to embed, so only lemmas of the listed parts of speech are kept,
+// forms keep only the part of speech and, if corpus files are given, only lemmas with a form
+// met in the corpus are kept.
+//
+// Run it from the repository root:
+//
+//	go run ./internal/pkg/tokenizer/pkg/lemmatizer/gen -in dict.opcorpora.txt.bz2 -corpus 'news/*.txt'
+package main
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// group represents lemma group of the dictionary, the first form is the lemma
+type group struct {
+	id    string
+	forms []string
+	pos   []string
+}
+
+func main() {
+	var (
+		in     = flag.String("in", "dict.opcorpora.txt.bz2", "OpenCorpora dictionary in text format, .bz2 is decompressed")
+		out    = flag.String("out", "internal/pkg/tokenizer/pkg/lemmatizer/dict/ru.txt", "embedded dictionary")
+		pos    = flag.String("pos", "NOUN,ADJF,ADJS,COMP,VERB,INFN,PRTF,PRTS,GRND", "parts of speech of kept lemmas")
+		corpus = flag.String("corpus", "", "comma separated globs of text files, lemmas not met in them are dropped")
+	)
+
+	flag.Parse()
+
+	words, files, err := readCorpus(*corpus)
+	if err != nil {
+		log.Fatalf("failed to read corpus: %v", err)
+	}
+
+	if err := generate(*in, *out, strings.Split(*pos, ","), words, files); err != nil {
+		log.Fatalf("failed to generate dictionary: %v", err)
+	}
+}
+
+// generate converts the OpenCorpora dictionary to the embedded one
+func generate(in string, out string, pos []string, words map[string]struct{}, files int) error {
+	src, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	var r io.Reader = src
+	if strings.HasSuffix(in, ".bz2") {
+		r = bzip2.NewReader(src)
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+
+	fmt.Fprintf(w, "# Russian dictionary of lemmatizer generated by lemmatizer/gen from %s, do not edit\n", filepath.Base(in))
+	fmt.Fprintf(w, "# parts of speech: %s\n", strings.Join(pos, ", "))
+
+	if words != nil {
+		fmt.Fprintf(w, "# only lemmas met in %d corpus files\n", files)
+	}
+
+	var total, kept int
+
+	err = readGroups(r, func(g *group) error {
+		total++
+
+		if !slices.Contains(pos, g.pos[0]) || (words != nil && !metIn(g, words)) {
+			return nil
+		}
+
+		kept++
+
+		fmt.Fprintf(w, "\n%s\n", g.id)
+		for i, form := range g.forms {
+			fmt.Fprintf(w, "%s\t%s\n", form, g.pos[i])
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", in, err)
+	}
+
+	log.Printf("kept %d of %d lemmas", kept, total)
+
+	return w.Flush()
+}
+
+// readGroups reads lemma groups of the dictionary: groups are separated by empty lines, the first line
+// of the group is its id and every form line is "FORM<TAB>POS,grammemes grammemes",
+// forms repeated with other grammemes are kept once
+func readGroups(r io.Reader, fn func(g *group) error) error {
+	var g *group
+
+	flush := func() error {
+		if g == nil || len(g.forms) == 0 {
+			g = nil
+			return nil
+		}
+
+		err := fn(g)
+		g = nil
+
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			if err := flush(); err != nil {
+				return err
+			}
+
+			continue
+		case g == nil:
+			g = &group{id: line}
+			continue
+		}
+
+		form, tags, _ := strings.Cut(line, "\t")
+		if slices.Contains(g.forms, form) {
+			continue
+		}
+
+		pos, _, _ := strings.Cut(tags, ",")
+		pos, _, _ = strings.Cut(pos, " ")
+
+		g.forms = append(g.forms, form)
+		g.pos = append(g.pos, pos)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// metIn checks if any form of the group is met in the corpus
+func metIn(g *group, words map[string]struct{}) bool {
+	for _, form := range g.forms {
+		if _, ok := words[normalize(form)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readCorpus returns words of the corpus files and number of the files, nil words if no globs are given
+func readCorpus(globs string) (map[string]struct{}, int, error) {
+	if globs == "" {
+		return nil, 0, nil
+	}
+
+	var (
+		words = make(map[string]struct{})
+		files int
+	)
+
+	for _, glob := range strings.Split(globs, ",") {
+		paths, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			for _, word := range strings.FieldsFunc(string(data), func(r rune) bool { return !unicode.IsLetter(r) }) {
+				words[normalize(word)] = struct{}{}
+			}
+
+			files++
+		}
+	}
+
+	if files == 0 {
+		return nil, 0, fmt.Errorf("no files match %s", globs)
+	}
+
+	return words, files, nil
+}
+
+// normalize upper-cases the word and replaces Ё with Е as lemmatizer does
+func normalize(word string) string {
+	return strings.ReplaceAll(strings.ToUpper(word), "Ё", "Е")
+}
@@ -0,0 +1,140 @@
+package lemmatizer
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
+//go:embed dict/ru.txt
+var russianDict []byte
+
+// Stemmer defines the interface of the stemmer used for words missing in the dictionary.
+type Stemmer interface {
+	Stem(token string) (string, error)
+}
+
+// LanguageStemmer defines the interface of the stemmer which takes language of the token from the caller.
+type LanguageStemmer interface {
+	StemLanguage(token string, language textutil.Language) (string, error)
+}
+
+// Lemmatizer replaces Russian words with their dictionary form (lemma), words of other languages
+// and words missing in the dictionary are passed to the fallback stemmer.
+// Lemmatizer is experimental: the embedded dictionary covers only a few dozen frequent words,
+// so it is useful only with the dictionary generated by lemmatizer/gen or the full OpenCorpora
+// dictionary loaded from file.
+type Lemmatizer struct {
+	// lemmas maps word form to its lemma
+	lemmas   map[string]string
+	fallback Stemmer
+}
+
+// New creates a new Lemmatizer with the embedded Russian dictionary and dictionaries from files,
+// files should be in OpenCorpora text format.
+func New(fallback Stemmer, files ...string) (*Lemmatizer, error) {
+	l := &Lemmatizer{
+		lemmas:   make(map[string]string),
+		fallback: fallback,
+	}
+
+	if err := l.load(bytes.NewReader(russianDict)); err != nil {
+		return nil, fmt.Errorf("failed to load embedded dictionary: %w", err)
+	}
+
+	for _, path := range files {
+		if err := l.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// Stem returns the lemma of the token, the fallback stemmer is used if lemma is unknown.
+func (l *Lemmatizer) Stem(token string) (string, error) {
+	return l.StemLanguage(token, textutil.DetectLanguage(token))
+}
+
+// StemLanguage returns the lemma of the token of the given language, the fallback stemmer
+// is used if lemma is unknown.
+func (l *Lemmatizer) StemLanguage(token string, language textutil.Language) (string, error) {
+	token = strings.ToLower(token)
+
+	if language == textutil.Russian {
+		if lemma, ok := l.lemmas[normalize(token)]; ok {
+			return lemma, nil
+		}
+	}
+
+	if stemmer, ok := l.fallback.(LanguageStemmer); ok {
+		return stemmer.StemLanguage(token, language)
+	}
+
+	return l.fallback.Stem(token)
+}
+
+// loadFile loads the dictionary from the file
+func (l *Lemmatizer) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open dictionary: %w", err)
+	}
+	defer file.Close()
+
+	if err := l.load(file); err != nil {
+		return fmt.Errorf("failed to load dictionary %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// load parses the dictionary in OpenCorpora text format: groups of forms are separated by empty lines,
+// the first line of the group is its id, the first form is the lemma and every form line is
+// "FORM<TAB>grammemes", a form of several lemmas keeps the first one.
+func (l *Lemmatizer) load(r io.Reader) error {
+	var (
+		lemma   string
+		inGroup bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "#"):
+			continue
+		case line == "":
+			lemma, inGroup = "", false
+			continue
+		case !inGroup:
+			inGroup = true // group id
+			continue
+		}
+
+		form, _, _ := strings.Cut(line, "\t")
+		form = normalize(strings.ToLower(form))
+
+		if lemma == "" {
+			lemma = form
+		}
+
+		if _, ok := l.lemmas[form]; !ok {
+			l.lemmas[form] = lemma
+		}
+	}
+
+	return scanner.Err()
+}
+
+// normalize replaces ё with е, so words are found regardless of spelling
+func normalize(word string) string {
+	return strings.ReplaceAll(word, "ё", "е")
+}
@@ -0,0 +1,87 @@
+package lemmatizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
+// fallbackStemmer marks tokens passed to the fallback stemmer
+type fallbackStemmer struct{}
+
+func (fallbackStemmer) Stem(token string) (string, error) {
+	return "stem:" + token, nil
+}
+
+func (fallbackStemmer) StemLanguage(token string, language textutil.Language) (string, error) {
+	return "stem:" + string(language) + ":" + token, nil
+}
+
+func TestLemmatizer(t *testing.T) {
+	lemm, err := New(fallbackStemmer{})
+	if err != nil {
+		t.Fatalf("failed to create lemmatizer: %v", err)
+	}
+
+	tests := []struct {
+		token    string
+		language textutil.Language
+		expected string
+	}{
+		{token: "новости", language: textutil.Russian, expected: "новость"},
+		{token: "Людьми", language: textutil.Russian, expected: "человек"},
+		// ё and е are the same letter for the dictionary
+		{token: "рублем", language: textutil.Russian, expected: "рубль"},
+		{token: "рублём", language: textutil.Russian, expected: "рубль"},
+		// unknown words and words of other languages are passed to the fallback stemmer
+		{token: "криптовалюты", language: textutil.Russian, expected: "stem:russian:криптовалюты"},
+		{token: "новости", language: textutil.Ukrainian, expected: "stem:ukrainian:новости"},
+		{token: "news", language: textutil.English, expected: "stem:english:news"},
+	}
+
+	for _, tt := range tests {
+		got, err := lemm.StemLanguage(tt.token, tt.language)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != tt.expected {
+			t.Errorf("StemLanguage(%q, %s) = %q, expected %q", tt.token, tt.language, got, tt.expected)
+		}
+	}
+}
+
+func TestLemmatizerDictionaryFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dict.opcorpora.txt")
+
+	dict := "# extra dictionary\n\n" +
+		"1\nКРИПТОВАЛЮТА\tNOUN,inan,femn sing,nomn\nКРИПТОВАЛЮТЫ\tNOUN,inan,femn sing,gent\n\n" +
+		// forms of embedded lemmas keep the embedded lemma
+		"2\nНОВОСТИ\tNOUN,inan,femn plur,nomn\n"
+
+	if err := os.WriteFile(path, []byte(dict), 0o600); err != nil {
+		t.Fatalf("failed to write dictionary: %v", err)
+	}
+
+	lemm, err := New(fallbackStemmer{}, path)
+	if err != nil {
+		t.Fatalf("failed to create lemmatizer: %v", err)
+	}
+
+	for token, expected := range map[string]string{"криптовалюты": "криптовалюта", "новости": "новость"} {
+		got, err := lemm.StemLanguage(token, textutil.Russian)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != expected {
+			t.Errorf("StemLanguage(%q) = %q, expected %q", token, got, expected)
+		}
+	}
+
+	if _, err := New(fallbackStemmer{}, filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Fatal("expected error of missing dictionary file")
+	}
+}
@@ -0,0 +1,60 @@
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+)
+
+const (
+	// Size is the number of bits in the fingerprint
+	Size = 64
+	// Bands is the number of bands the fingerprint is split into, fingerprints which differ in less
+	// than Bands bits have at least one equal band, so bands are used to look up candidates
+	Bands = 4
+	// bandSize is the number of bits in a band
+	bandSize = Size / Bands
+)
+
+// Compute returns SimHash fingerprint of the features, e.g. words of the message,
+// similar sets of features have fingerprints with small Hamming distance
+func Compute(features []string) uint64 {
+	var weights [Size]int
+
+	for _, feature := range features {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+
+		for i := range Size {
+			if sum&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for i, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << i
+		}
+	}
+
+	return fingerprint
+}
+
+// Distance returns Hamming distance between fingerprints
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Split splits the fingerprint into bands of 16 bits
+func Split(fingerprint uint64) [Bands]uint16 {
+	var bands [Bands]uint16
+	for i := range Bands {
+		bands[i] = uint16(fingerprint >> (i * bandSize))
+	}
+
+	return bands
+}
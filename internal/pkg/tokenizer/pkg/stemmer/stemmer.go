@@ -5,33 +5,63 @@ import (
 	"strings"
 
 	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
-	"github.com/keenywheels/backend/internal/pkg/tokenizer/stages"
 
 	"github.com/kljensen/snowball"
 )
 
-var (
-	DefaultStemmer = New(textutil.DefaultLanguage)
+var DefaultStemmer = New(textutil.DefaultLanguage)
 
-	_ = stages.Stemmer(&Stemmer{})
-)
+// snowballLanguages holds languages supported by snowball, words of other languages are only lowercased
+var snowballLanguages = map[textutil.Language]struct{}{
+	textutil.English: {},
+	textutil.Russian: {},
+}
 
 // Stemmer provides stemming functionality for tokens.
 type Stemmer struct {
 	defaultLanguage textutil.Language
+
+	// languages limits languages which are stemmed, tokens of other languages are only lowercased
+	languages map[textutil.Language]struct{}
 }
 
-// New creates a new Stemmer with the specified default language.
-func New(defaultLanguage textutil.Language) *Stemmer {
-	return &Stemmer{
+// New creates a new Stemmer with the specified default language, if languages are provided,
+// only tokens of these languages are stemmed.
+func New(defaultLanguage textutil.Language, languages ...textutil.Language) *Stemmer {
+	s := &Stemmer{
 		defaultLanguage: getLanguage(defaultLanguage),
 	}
+
+	if len(languages) > 0 {
+		s.languages = make(map[textutil.Language]struct{}, len(languages))
+		for _, language := range languages {
+			s.languages[language] = struct{}{}
+		}
+	}
+
+	return s
 }
 
-// Stem executes the stemming process on the provided token.
+// Stem executes the stemming process on the provided token of unknown language, the default language
+// is used for tokens written in its script, language of other tokens is detected by the token.
 func (s *Stemmer) Stem(token string) (string, error) {
+	return s.StemLanguage(token, textutil.ResolveLanguage(token, s.defaultLanguage))
+}
+
+// StemLanguage executes the stemming process on the provided token of the given language,
+// e.g. language of the whole message.
+func (s *Stemmer) StemLanguage(token string, language textutil.Language) (string, error) {
 	token = strings.ToLower(token)
-	language := textutil.DetectLanguage(token)
+
+	if s.languages != nil {
+		if _, ok := s.languages[language]; !ok {
+			return token, nil
+		}
+	}
+
+	if _, ok := snowballLanguages[language]; !ok {
+		return token, nil
+	}
 
 	stemmedToken, err := snowball.Stem(token, string(language), true)
 	if err != nil {
@@ -41,6 +71,12 @@ func (s *Stemmer) Stem(token string) (string, error) {
 	return stemmedToken, nil
 }
 
+// Supports checks if snowball stemmer is available for the language.
+func Supports(language textutil.Language) bool {
+	_, ok := snowballLanguages[language]
+	return ok
+}
+
 // getLanguage returns the provided language or the default language if none is provided.
 func getLanguage(language textutil.Language) textutil.Language {
 	if language == "" {
@@ -0,0 +1,154 @@
+package stopwords
+
+// German stopwords dictionary
+var German = map[string]string{
+	"aber":     "",
+	"alle":     "",
+	"allem":    "",
+	"allen":    "",
+	"aller":    "",
+	"alles":    "",
+	"als":      "",
+	"also":     "",
+	"am":       "",
+	"an":       "",
+	"andere":   "",
+	"anderen":  "",
+	"auch":     "",
+	"auf":      "",
+	"aus":      "",
+	"bei":      "",
+	"beim":     "",
+	"bin":      "",
+	"bis":      "",
+	"bist":     "",
+	"da":       "",
+	"damit":    "",
+	"dann":     "",
+	"das":      "",
+	"dass":     "",
+	"dein":     "",
+	"deine":    "",
+	"dem":      "",
+	"den":      "",
+	"denn":     "",
+	"der":      "",
+	"des":      "",
+	"dich":     "",
+	"die":      "",
+	"dies":     "",
+	"diese":    "",
+	"diesem":   "",
+	"diesen":   "",
+	"dieser":   "",
+	"dieses":   "",
+	"dir":      "",
+	"doch":     "",
+	"dort":     "",
+	"du":       "",
+	"durch":    "",
+	"ein":      "",
+	"eine":     "",
+	"einem":    "",
+	"einen":    "",
+	"einer":    "",
+	"eines":    "",
+	"er":       "",
+	"es":       "",
+	"etwas":    "",
+	"euch":     "",
+	"euer":     "",
+	"für":      "",
+	"gegen":    "",
+	"hab":      "",
+	"habe":     "",
+	"haben":    "",
+	"hat":      "",
+	"hatte":    "",
+	"hatten":   "",
+	"hier":     "",
+	"hin":      "",
+	"hinter":   "",
+	"ich":      "",
+	"ihm":      "",
+	"ihn":      "",
+	"ihnen":    "",
+	"ihr":      "",
+	"ihre":     "",
+	"im":       "",
+	"in":       "",
+	"indem":    "",
+	"ins":      "",
+	"ist":      "",
+	"jede":     "",
+	"jedem":    "",
+	"jeden":    "",
+	"jeder":    "",
+	"jedes":    "",
+	"jetzt":    "",
+	"kann":     "",
+	"kein":     "",
+	"keine":    "",
+	"können":   "",
+	"man":      "",
+	"manche":   "",
+	"mein":     "",
+	"meine":    "",
+	"mich":     "",
+	"mir":      "",
+	"mit":      "",
+	"muss":     "",
+	"nach":     "",
+	"nicht":    "",
+	"nichts":   "",
+	"noch":     "",
+	"nun":      "",
+	"nur":      "",
+	"ob":       "",
+	"oder":     "",
+	"ohne":     "",
+	"sehr":     "",
+	"sein":     "",
+	"seine":    "",
+	"sich":     "",
+	"sie":      "",
+	"sind":     "",
+	"so":       "",
+	"solche":   "",
+	"soll":     "",
+	"sondern":  "",
+	"sonst":    "",
+	"um":       "",
+	"und":      "",
+	"uns":      "",
+	"unser":    "",
+	"unter":    "",
+	"viel":     "",
+	"vom":      "",
+	"von":      "",
+	"vor":      "",
+	"war":      "",
+	"waren":    "",
+	"warum":    "",
+	"was":      "",
+	"weil":     "",
+	"welche":   "",
+	"wenn":     "",
+	"wer":      "",
+	"werde":    "",
+	"werden":   "",
+	"wie":      "",
+	"wieder":   "",
+	"will":     "",
+	"wir":      "",
+	"wird":     "",
+	"wo":       "",
+	"wollen":   "",
+	"würde":    "",
+	"zu":       "",
+	"zum":      "",
+	"zur":      "",
+	"zwar":     "",
+	"zwischen": "",
+	"über":     "",
+}
@@ -1,9 +1,30 @@
 package stopwords
 
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
 // DictRegistry holds all available stopword dictionaries
 var DictRegistry = []map[string]string{
 	English,
 	Russian,
+	Ukrainian,
+	Kazakh,
+	German,
+}
+
+// ByLanguage maps language to its stopword dictionary
+var ByLanguage = map[textutil.Language]map[string]string{
+	textutil.English:   English,
+	textutil.Russian:   Russian,
+	textutil.Ukrainian: Ukrainian,
+	textutil.Kazakh:    Kazakh,
+	textutil.German:    German,
 }
 
 // All is a merged dictionary of all stopwords
@@ -17,3 +38,30 @@ func init() {
 		}
 	}
 }
+
+// LoadFile loads stopwords from the file with a word per line, empty lines and lines starting with # are skipped
+func LoadFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stopwords file: %w", err)
+	}
+	defer file.Close()
+
+	dict := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+
+		dict[word] = ""
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stopwords file %s: %w", path, err)
+	}
+
+	return dict, nil
+}
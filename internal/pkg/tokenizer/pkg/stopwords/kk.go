@@ -0,0 +1,85 @@
+package stopwords
+
+// Kazakh stopwords dictionary
+var Kazakh = map[string]string{
+	"ал":      "",
+	"арқылы":  "",
+	"ба":      "",
+	"бар":     "",
+	"барлық":  "",
+	"бе":      "",
+	"бен":     "",
+	"бойынша": "",
+	"болады":  "",
+	"болды":   "",
+	"болса":   "",
+	"болып":   "",
+	"болған":  "",
+	"біз":     "",
+	"бізге":   "",
+	"біздің":  "",
+	"бір":     "",
+	"бірақ":   "",
+	"бұл":     "",
+	"да":      "",
+	"де":      "",
+	"дейін":   "",
+	"егер":    "",
+	"еді":     "",
+	"екен":    "",
+	"емес":    "",
+	"енді":    "",
+	"жоқ":     "",
+	"және":    "",
+	"кейін":   "",
+	"кім":     "",
+	"ма":      "",
+	"ме":      "",
+	"мен":     "",
+	"мені":    "",
+	"менің":   "",
+	"мұны":    "",
+	"не":      "",
+	"неге":    "",
+	"немесе":  "",
+	"неше":    "",
+	"ол":      "",
+	"олар":    "",
+	"олардың": "",
+	"оларға":  "",
+	"оны":     "",
+	"оның":    "",
+	"осы":     "",
+	"оған":    "",
+	"па":      "",
+	"пе":      "",
+	"пен":     "",
+	"себебі":  "",
+	"секілді": "",
+	"сен":     "",
+	"сендер":  "",
+	"сенің":   "",
+	"сияқты":  "",
+	"сол":     "",
+	"соны":    "",
+	"сіз":     "",
+	"сіздер":  "",
+	"сіздің":  "",
+	"та":      "",
+	"тағы":    "",
+	"те":      "",
+	"туралы":  "",
+	"ғана":    "",
+	"ғой":     "",
+	"қай":     "",
+	"қайда":   "",
+	"қандай":  "",
+	"қанша":   "",
+	"қашан":   "",
+	"үшін":    "",
+	"әлде":    "",
+	"әлі":     "",
+	"әр":      "",
+	"әрбір":   "",
+	"өйткені": "",
+}
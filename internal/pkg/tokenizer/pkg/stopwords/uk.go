@@ -0,0 +1,135 @@
+package stopwords
+
+// Ukrainian stopwords dictionary
+var Ukrainian = map[string]string{
+	"а":      "",
+	"аби":    "",
+	"або":    "",
+	"адже":   "",
+	"але":    "",
+	"би":     "",
+	"бо":     "",
+	"був":    "",
+	"була":   "",
+	"були":   "",
+	"було":   "",
+	"бути":   "",
+	"більш":  "",
+	"біля":   "",
+	"в":      "",
+	"вам":    "",
+	"вас":    "",
+	"ваш":    "",
+	"ваша":   "",
+	"ваше":   "",
+	"ваші":   "",
+	"весь":   "",
+	"вже":    "",
+	"ви":     "",
+	"вона":   "",
+	"вони":   "",
+	"воно":   "",
+	"все":    "",
+	"всього": "",
+	"вся":    "",
+	"всі":    "",
+	"від":    "",
+	"де":     "",
+	"для":    "",
+	"до":     "",
+	"з":      "",
+	"за":     "",
+	"зі":     "",
+	"його":   "",
+	"коли":   "",
+	"котрий": "",
+	"крім":   "",
+	"ледве":  "",
+	"лише":   "",
+	"мене":   "",
+	"мені":   "",
+	"ми":     "",
+	"моя":    "",
+	"мої":    "",
+	"між":    "",
+	"мій":    "",
+	"на":     "",
+	"навіть": "",
+	"над":    "",
+	"нам":    "",
+	"нас":    "",
+	"наш":    "",
+	"наша":   "",
+	"наше":   "",
+	"наші":   "",
+	"не":     "",
+	"нею":    "",
+	"ним":    "",
+	"них":    "",
+	"ні":     "",
+	"ніби":   "",
+	"нібито": "",
+	"ніж":    "",
+	"о":      "",
+	"от":     "",
+	"по":     "",
+	"поки":   "",
+	"при":    "",
+	"про":    "",
+	"під":    "",
+	"після":  "",
+	"раз":    "",
+	"разом":  "",
+	"себе":   "",
+	"так":    "",
+	"така":   "",
+	"таке":   "",
+	"такий":  "",
+	"також":  "",
+	"там":    "",
+	"те":     "",
+	"тебе":   "",
+	"теж":    "",
+	"ти":     "",
+	"тим":    "",
+	"то":     "",
+	"тобто":  "",
+	"тобі":   "",
+	"того":   "",
+	"той":    "",
+	"тому":   "",
+	"ту":     "",
+	"тут":    "",
+	"у":      "",
+	"хоч":    "",
+	"хоча":   "",
+	"хто":    "",
+	"це":     "",
+	"цей":    "",
+	"цим":    "",
+	"цих":    "",
+	"цього":  "",
+	"цю":     "",
+	"ці":     "",
+	"цієї":   "",
+	"через":  "",
+	"чи":     "",
+	"що":     "",
+	"щоб":    "",
+	"як":     "",
+	"яка":    "",
+	"який":   "",
+	"якщо":   "",
+	"які":    "",
+	"є":      "",
+	"і":      "",
+	"із":     "",
+	"інша":   "",
+	"інший":  "",
+	"інші":   "",
+	"їй":     "",
+	"їм":     "",
+	"їх":     "",
+	"їхній":  "",
+	"її":     "",
+}
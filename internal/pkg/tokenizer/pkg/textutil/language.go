@@ -1,28 +1,104 @@
 package textutil
 
-import "unicode"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 // Language represents a language type
 type Language string
 
 // Supported languages
 const (
-	English Language = "english"
-	Russian Language = "russian"
+	English   Language = "english"
+	Russian   Language = "russian"
+	Ukrainian Language = "ukrainian"
+	Kazakh    Language = "kazakh"
+	German    Language = "german"
 )
 
 var DefaultLanguage = Russian
 
-// DetectLanguage detects the language of the given token based on its characters
+// scripts maps language to the script of its alphabet
+var scripts = map[Language]*unicode.RangeTable{
+	English:   unicode.Latin,
+	Russian:   unicode.Cyrillic,
+	Ukrainian: unicode.Cyrillic,
+	Kazakh:    unicode.Cyrillic,
+	German:    unicode.Latin,
+}
+
+// letters maps letters which are specific to the language alphabet to the language
+var letters = map[rune]Language{
+	'ґ': Ukrainian, 'є': Ukrainian, 'ї': Ukrainian,
+	'ә': Kazakh, 'ғ': Kazakh, 'қ': Kazakh, 'ң': Kazakh, 'ө': Kazakh, 'ұ': Kazakh, 'ү': Kazakh, 'һ': Kazakh,
+	'ä': German, 'ö': German, 'ü': German, 'ß': German,
+}
+
+// DetectLanguage detects the language of the given token based on its characters,
+// script of the first letter is used unless the token has letters specific to a language
 func DetectLanguage(token string) Language {
+	language := Language("")
+
 	for _, t := range token {
+		if specific, ok := letters[unicode.ToLower(t)]; ok {
+			return specific
+		}
+
+		if language != "" {
+			continue
+		}
+
 		switch {
 		case unicode.In(t, unicode.Cyrillic):
-			return Russian
+			language = Russian
 		case unicode.In(t, unicode.Latin):
-			return English
+			language = English
 		}
 	}
 
-	return DefaultLanguage
+	if language == "" {
+		return DefaultLanguage
+	}
+
+	return language
+}
+
+// ResolveLanguage returns the language of the message if the token is written in its script,
+// otherwise language is detected by the token itself, e.g. english brand in russian message
+func ResolveLanguage(token string, message Language) Language {
+	script := message.Script()
+	if script == nil {
+		return DetectLanguage(token)
+	}
+
+	for _, t := range token {
+		if !unicode.IsLetter(t) {
+			continue
+		}
+
+		if unicode.In(t, script) {
+			return message
+		}
+
+		break
+	}
+
+	return DetectLanguage(token)
+}
+
+// Script returns the script of the language alphabet, nil if language is not supported
+func (l Language) Script() *unicode.RangeTable {
+	return scripts[l]
+}
+
+// ParseLanguage returns the supported language by its name
+func ParseLanguage(name string) (Language, error) {
+	language := Language(strings.ToLower(name))
+	if _, ok := scripts[language]; !ok {
+		return "", fmt.Errorf("unsupported language %q", name)
+	}
+
+	return language, nil
 }
@@ -1,28 +1,68 @@
 package tokenizer
 
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
 // PipelineStage defines the interface for a stage in the token processing pipeline.
 type PipelineStage interface {
 	Execute(tokens []Token) []Token
 	Continue(tokens []Token) []Token
 	SetNext(stage PipelineStage)
+	SetErrorPolicy(policy ErrorPolicy)
+	Stats() StageStats
+	Err() error
 }
 
 var _ = PipelineStage(&Stage{})
 
-// Stage represents a processing stage in the token pipeline.
+// Stage represents a processing stage in the token pipeline,
+// streaming pipeline may execute the stage on chunks of tokens concurrently.
 type Stage struct {
 	NextStage    PipelineStage
 	CallbackFunc func(token *Token) error
+
+	policy ErrorPolicy
+
+	mu    sync.Mutex
+	stats StageStats
+	err   error
 }
 
-// Execute processes the tokens using the stage's callback function and continues to the next stage.
+// Execute processes the tokens using the stage's callback function and continues to the next stage,
+// failed tokens are handled according to the error policy of the stage.
 func (s *Stage) Execute(tokens []Token) []Token {
+	var (
+		start            = time.Now()
+		filtered, failed int
+	)
+
 	for i := range tokens {
+		wasFiltered := tokens[i].IsFiltered()
+
 		if err := s.CallbackFunc(&tokens[i]); err != nil {
-			continue
+			failed++
+
+			switch s.policy {
+			case ErrorPolicyFilter:
+				tokens[i].Filter()
+			case ErrorPolicyAbort:
+				s.abort(fmt.Errorf("failed to process token %q: %w", tokens[i].Target, err))
+				s.record(i+1, filtered, failed, time.Since(start))
+
+				return tokens
+			}
+		}
+
+		if !wasFiltered && tokens[i].IsFiltered() {
+			filtered++
 		}
 	}
 
+	s.record(len(tokens), filtered, failed, time.Since(start))
+
 	return s.Continue(tokens)
 }
 
@@ -39,3 +79,58 @@ func (s *Stage) Continue(tokens []Token) []Token {
 func (s *Stage) SetNext(stage PipelineStage) {
 	s.NextStage = stage
 }
+
+// SetErrorPolicy sets the policy of handling tokens the callback failed on, errors are ignored by default.
+func (s *Stage) SetErrorPolicy(policy ErrorPolicy) {
+	s.policy = policy
+}
+
+// Streamable checks if the stage processes every token independently of other tokens,
+// so streaming pipeline can execute it on chunks of tokens concurrently. Stages overriding Execute
+// to process the whole message at once must not have callback or must override Streamable.
+func (s *Stage) Streamable() bool {
+	return s.CallbackFunc != nil
+}
+
+// Stats returns statistics of the stage.
+func (s *Stage) Stats() StageStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+// Record adds tokens and time of the stage execution to the statistics,
+// it is used by stages which override Execute and don't run the callback.
+func (s *Stage) Record(in, filtered int, elapsed time.Duration) {
+	s.record(in, filtered, 0, elapsed)
+}
+
+// record adds tokens, errors and time of the stage execution to the statistics
+func (s *Stage) record(in, filtered, failed int, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.In += in
+	s.stats.Filtered += filtered
+	s.stats.Errors += failed
+	s.stats.Elapsed += elapsed
+}
+
+// abort saves the error the stage aborted the pipeline run with, the first error is kept
+func (s *Stage) abort(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// Err returns the error the stage aborted the pipeline run with, nil if the run was not aborted.
+func (s *Stage) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
@@ -0,0 +1,74 @@
+package stages
+
+import (
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/metrics"
+)
+
+// Names of built-in stages
+const (
+	StageLanguage   = "language"
+	StageNormalizer = "normalizer"
+	StageFilter     = "filter"
+	StageStemmer    = "stemmer"
+	StageNGram      = "ngram"
+	StageMetric     = "metric"
+)
+
+// StageConfig represents config of a pipeline stage, every stage uses only its own parameters
+type StageConfig struct {
+	Name          string   `mapstructure:"name"`
+	Mode          string   `mapstructure:"mode"`
+	MinLength     int      `mapstructure:"min_length"`
+	Languages     []string `mapstructure:"languages"`
+	StopwordFiles []string `mapstructure:"stopword_files"`
+	Metrics       []string `mapstructure:"metrics"`
+
+	// policy of handling tokens the stage failed on: ignore (default), filter or abort
+	OnError string `mapstructure:"on_error"`
+
+	// filter stage parameter: stopword files of the language, e.g. {"ukrainian": ["uk.txt"]}
+	StopwordDicts map[string][]string `mapstructure:"stopword_dicts"`
+
+	// stemmer stage parameters: languages which are lemmatized by dictionary instead of stemming
+	// and extra dictionaries in OpenCorpora text format. Lemmatization is experimental and off by default,
+	// the embedded dictionary is a small starter set, so the full dictionary should be set in DictionaryFiles
+	Lemmatize       []string `mapstructure:"lemmatize"`
+	DictionaryFiles []string `mapstructure:"dictionary_files"`
+
+	// n-gram stage parameters: min_count and min_pmi are computed over messages of the last window
+	MaxN     int           `mapstructure:"max_n"`
+	MinCount int           `mapstructure:"min_count"`
+	MinPMI   float64       `mapstructure:"min_pmi"`
+	Window   time.Duration `mapstructure:"window"`
+}
+
+// StreamingConfig represents config of the streaming pipeline, tokens are processed in chunks
+// of chunk_size tokens by workers goroutines per stage and context of tokens is computed lazily
+type StreamingConfig struct {
+	Enabled   bool `mapstructure:"enabled"`
+	ChunkSize int  `mapstructure:"chunk_size"`
+	Workers   int  `mapstructure:"workers"`
+}
+
+// PipelineConfig represents config of the tokenizer pipeline, stages are executed in the listed order
+type PipelineConfig struct {
+	ContextWindow int             `mapstructure:"context_window"`
+	Stages        []StageConfig   `mapstructure:"stages"`
+	Streaming     StreamingConfig `mapstructure:"streaming"`
+}
+
+// DefaultPipelineConfig returns config of the default pipeline:
+// language detection, normalizer, filter, snowball stemmer and interest metric
+func DefaultPipelineConfig() PipelineConfig {
+	return PipelineConfig{
+		Stages: []StageConfig{
+			{Name: StageLanguage},
+			{Name: StageNormalizer},
+			{Name: StageFilter, MinLength: DefaultTokenMinLength},
+			{Name: StageStemmer},
+			{Name: StageMetric, Metrics: []string{metrics.InterestMetricName}},
+		},
+	}
+}
@@ -0,0 +1,354 @@
+package stages
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/metrics"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/langdetect"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/lemmatizer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/stemmer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/stopwords"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
+var (
+	_ = LanguageStemmer(&stemmer.Stemmer{})
+	_ = LanguageStemmer(&lemmatizer.Lemmatizer{})
+	_ = LanguageDetector(&langdetect.Detector{})
+)
+
+// StageBuilder creates a stage for a single pipeline run, metrics are collected separately for every run
+type StageBuilder func(metrics map[string]metrics.Metric) tokenizer.PipelineStage
+
+// Factory validates the stage config and returns builder of the stage,
+// expensive preparation (e.g. loading stopword files) is done once by the factory
+type Factory func(cfg StageConfig) (StageBuilder, error)
+
+var (
+	mu sync.RWMutex
+
+	// factories maps stage name to its factory
+	factories = map[string]Factory{
+		StageLanguage:   newLanguageBuilder,
+		StageNormalizer: newNormalizerBuilder,
+		StageFilter:     newFilterBuilder,
+		StageStemmer:    newStemmerBuilder,
+		StageNGram:      newNGramBuilder,
+		StageMetric:     newMetricBuilder,
+	}
+)
+
+// Register registers the stage factory by name, so the stage can be used in pipeline config
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	factories[name] = factory
+}
+
+// getFactory returns the stage factory by name
+func getFactory(name string) (Factory, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	factory, ok := factories[name]
+
+	return factory, ok
+}
+
+// PipelineFactory builds tokenizer pipelines from config
+type PipelineFactory struct {
+	contextWindow int
+	streaming     StreamingConfig
+	metrics       []string
+	names         []string
+	policies      []tokenizer.ErrorPolicy
+	builders      []StageBuilder
+}
+
+// NewPipelineFactory validates pipeline config and creates a factory of pipelines,
+// default pipeline is used if no stages are configured
+func NewPipelineFactory(cfg PipelineConfig) (*PipelineFactory, error) {
+	if len(cfg.Stages) == 0 {
+		cfg.Stages = DefaultPipelineConfig().Stages
+	}
+
+	f := &PipelineFactory{
+		contextWindow: cfg.ContextWindow,
+		streaming:     cfg.Streaming,
+		names:         make([]string, 0, len(cfg.Stages)),
+		policies:      make([]tokenizer.ErrorPolicy, 0, len(cfg.Stages)),
+		builders:      make([]StageBuilder, 0, len(cfg.Stages)),
+	}
+
+	if f.contextWindow <= 0 {
+		f.contextWindow = tokenizer.DefaultContextWindow
+	}
+
+	metricNames := make(map[string]struct{})
+
+	for i, stageCfg := range cfg.Stages {
+		factory, ok := getFactory(stageCfg.Name)
+		if !ok {
+			return nil, fmt.Errorf("unknown stage %q at position %d", stageCfg.Name, i)
+		}
+
+		builder, err := factory(stageCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stage %q at position %d: %w", stageCfg.Name, i, err)
+		}
+
+		policy, err := tokenizer.ParseErrorPolicy(stageCfg.OnError)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stage %q at position %d: %w", stageCfg.Name, i, err)
+		}
+
+		for _, name := range stageCfg.Metrics {
+			if _, err := metrics.New(name); err != nil {
+				return nil, fmt.Errorf("invalid stage %q at position %d: %w", stageCfg.Name, i, err)
+			}
+
+			metricNames[name] = struct{}{}
+		}
+
+		f.names = append(f.names, stageCfg.Name)
+		f.policies = append(f.policies, policy)
+		f.builders = append(f.builders, builder)
+	}
+
+	f.metrics = slices.Sorted(maps.Keys(metricNames))
+
+	return f, nil
+}
+
+// ContextWindow returns the context window of tokens
+func (f *PipelineFactory) ContextWindow() int {
+	return f.contextWindow
+}
+
+// Tokens splits the text into tokens for the pipeline, tokens have lazy context if streaming is enabled
+func (f *PipelineFactory) Tokens(text string) []tokenizer.Token {
+	tokenConfig := tokenizer.NewTokenConfig(tokenizer.DefaultTokenSource, f.contextWindow)
+
+	if f.streaming.Enabled {
+		return tokenizer.GetLazyTokens(text, tokenConfig)
+	}
+
+	return tokenizer.GetTokens(text, tokenConfig)
+}
+
+// HasMetric checks if the metric is collected by the pipeline
+func (f *PipelineFactory) HasMetric(name string) bool {
+	return slices.Contains(f.metrics, name)
+}
+
+// Build creates a new pipeline with its own metrics, returns metrics by name
+func (f *PipelineFactory) Build() (*tokenizer.Pipeline, map[string]metrics.Metric) {
+	registry := make(map[string]metrics.Metric, len(f.metrics))
+	for _, name := range f.metrics {
+		registry[name], _ = metrics.New(name) // names are validated by NewPipelineFactory
+	}
+
+	pipelineBuilder := tokenizer.NewPipelineBuilder()
+	if f.streaming.Enabled {
+		pipelineBuilder.Stream(tokenizer.StreamConfig{
+			ChunkSize: f.streaming.ChunkSize,
+			Workers:   f.streaming.Workers,
+		})
+	}
+
+	for i, builder := range f.builders {
+		stage := builder(registry)
+		stage.SetErrorPolicy(f.policies[i])
+
+		pipelineBuilder.AddNamedStage(f.names[i], stage)
+	}
+
+	return pipelineBuilder.Build(), registry
+}
+
+// newLanguageBuilder creates builder of the language detection stage, the configured languages
+// (all supported if not set) are the candidates
+func newLanguageBuilder(cfg StageConfig) (StageBuilder, error) {
+	languages, err := parseLanguages(cfg.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	detector, err := langdetect.New(languages...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(_ map[string]metrics.Metric) tokenizer.PipelineStage {
+		return NewLanguageStage(detector)
+	}, nil
+}
+
+// newNormalizerBuilder creates builder of the normalizer stage
+func newNormalizerBuilder(cfg StageConfig) (StageBuilder, error) {
+	if _, err := NewNormalizerStageWithMode(cfg.Mode); err != nil {
+		return nil, err
+	}
+
+	return func(_ map[string]metrics.Metric) tokenizer.PipelineStage {
+		stage, _ := NewNormalizerStageWithMode(cfg.Mode) // mode is validated above
+		return stage
+	}, nil
+}
+
+// newFilterBuilder creates builder of the filter stage, stopwords of the configured languages
+// (all languages if not set) are extended with stopwords from the language files,
+// stopwords from common files are removed regardless of the token language
+func newFilterBuilder(cfg StageConfig) (StageBuilder, error) {
+	languages, err := parseLanguages(cfg.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(languages) == 0 {
+		languages = slices.Collect(maps.Keys(stopwords.ByLanguage))
+	}
+
+	byLanguage := make(map[textutil.Language]map[string]string, len(languages))
+	for _, language := range languages {
+		langDict, ok := stopwords.ByLanguage[language]
+		if !ok {
+			return nil, fmt.Errorf("no stopwords for language %s", language)
+		}
+
+		byLanguage[language] = maps.Clone(langDict)
+	}
+
+	for name, paths := range cfg.StopwordDicts {
+		language, err := textutil.ParseLanguage(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := byLanguage[language]; !ok {
+			byLanguage[language] = make(map[string]string)
+		}
+
+		for _, path := range paths {
+			fileDict, err := stopwords.LoadFile(path)
+			if err != nil {
+				return nil, err
+			}
+
+			maps.Copy(byLanguage[language], fileDict)
+		}
+	}
+
+	common := make(map[string]string)
+	for _, path := range cfg.StopwordFiles {
+		fileDict, err := stopwords.LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		maps.Copy(common, fileDict)
+	}
+
+	return func(_ map[string]metrics.Metric) tokenizer.PipelineStage {
+		return NewLanguageFilterStage(cfg.MinLength, byLanguage, common)
+	}, nil
+}
+
+// newStemmerBuilder creates builder of the snowball stemmer stage, tokens are stemmed in language
+// of the message set by language stage, the first configured language is used if it is not set,
+// tokens of not configured languages are not stemmed, words of languages from lemmatize are replaced
+// with their dictionary form if it is known
+func newStemmerBuilder(cfg StageConfig) (StageBuilder, error) {
+	languages, err := parseLanguages(cfg.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	// tokens of such language would be silently kept as is
+	for _, language := range languages {
+		if !stemmer.Supports(language) {
+			return nil, fmt.Errorf("stemming is not supported for language %s", language)
+		}
+	}
+
+	var stm Stemmer = stemmer.DefaultStemmer
+	if len(languages) > 0 {
+		stm = stemmer.New(languages[0], languages...)
+	}
+
+	// only russian dictionary is available for now, stemming is used for unknown words
+	for _, name := range cfg.Lemmatize {
+		language, err := textutil.ParseLanguage(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if language != textutil.Russian {
+			return nil, fmt.Errorf("lemmatization is not supported for language %s", language)
+		}
+	}
+
+	if len(cfg.Lemmatize) > 0 {
+		lemm, err := lemmatizer.New(stm, cfg.DictionaryFiles...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create lemmatizer: %w", err)
+		}
+
+		stm = lemm
+	}
+
+	return func(_ map[string]metrics.Metric) tokenizer.PipelineStage {
+		return NewStemmerStage(stm)
+	}, nil
+}
+
+// newNGramBuilder creates builder of the n-gram stage, all pipelines share the corpus of messages
+func newNGramBuilder(cfg StageConfig) (StageBuilder, error) {
+	if cfg.MaxN == 1 || cfg.MaxN < 0 {
+		return nil, fmt.Errorf("invalid max n-gram size %d", cfg.MaxN)
+	}
+
+	corpus := NewPhraseCorpus(cfg.Window)
+
+	return func(_ map[string]metrics.Metric) tokenizer.PipelineStage {
+		return NewNGramStage(cfg.MaxN, cfg.MinCount, cfg.MinPMI, corpus)
+	}, nil
+}
+
+// newMetricBuilder creates builder of the metric stage
+func newMetricBuilder(cfg StageConfig) (StageBuilder, error) {
+	if len(cfg.Metrics) == 0 {
+		return nil, errors.New("no metrics to collect")
+	}
+
+	return func(registry map[string]metrics.Metric) tokenizer.PipelineStage {
+		stageMetrics := make([]metrics.Metric, 0, len(cfg.Metrics))
+		for _, name := range cfg.Metrics {
+			stageMetrics = append(stageMetrics, registry[name])
+		}
+
+		return NewMetricStage(stageMetrics...)
+	}, nil
+}
+
+// parseLanguages parses names of the languages
+func parseLanguages(names []string) ([]textutil.Language, error) {
+	languages := make([]textutil.Language, 0, len(names))
+
+	for _, name := range names {
+		language, err := textutil.ParseLanguage(name)
+		if err != nil {
+			return nil, err
+		}
+
+		languages = append(languages, language)
+	}
+
+	return languages, nil
+}
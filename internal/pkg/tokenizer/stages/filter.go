@@ -3,21 +3,54 @@ package stages
 import (
 	"github.com/keenywheels/backend/internal/pkg/tokenizer"
 	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/stopwords"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
 )
 
 const DefaultTokenMinLength = 3
 
 // NewFilterStage creates a new filtering stage that removes tokens
 func NewFilterStage(tokenMinLength int) *tokenizer.Stage {
+	return NewLanguageFilterStage(tokenMinLength, stopwords.ByLanguage, nil)
+}
+
+// NewFilterStageWithStopwords creates a new filtering stage that removes short tokens and the given stopwords
+func NewFilterStageWithStopwords(tokenMinLength int, stopwords map[string]string) *tokenizer.Stage {
+	return NewLanguageFilterStage(tokenMinLength, nil, stopwords)
+}
+
+// NewLanguageFilterStage creates a new filtering stage that removes short tokens, stopwords of the token
+// language and common stopwords, language of the token is detected by the token if it is not set by language stage
+func NewLanguageFilterStage(
+	tokenMinLength int,
+	byLanguage map[textutil.Language]map[string]string,
+	common map[string]string,
+) *tokenizer.Stage {
 	stage := &tokenizer.Stage{}
 
 	tokenMinLength = getTokenMinLength(tokenMinLength)
 	stage.CallbackFunc = func(token *tokenizer.Token) error {
-		if len([]rune(token.Target)) < tokenMinLength {
+		switch token.Kind() {
+		case tokenizer.KindNumber:
+			// plain numbers (prices, years, counters) are noise
 			token.Filter()
+		case tokenizer.KindCode, tokenizer.KindVersion:
+			// codes and versions are meaningful even if short, e.g. "5g"
+		default:
+			if len([]rune(token.Target)) < tokenMinLength {
+				token.Filter()
+			}
+		}
+
+		if _, isStop := common[token.Target]; isStop {
+			token.Filter()
+		}
+
+		language := token.Language()
+		if language == "" {
+			language = textutil.DetectLanguage(token.Target)
 		}
 
-		if _, isStop := stopwords.All[token.Target]; isStop {
+		if _, isStop := byLanguage[language][token.Target]; isStop {
 			token.Filter()
 		}
 
@@ -0,0 +1,51 @@
+package stages
+
+import (
+	"strings"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+)
+
+var _ = tokenizer.PipelineStage(&LanguageStage{})
+
+// LanguageDetector defines the interface for detecting language of the text.
+type LanguageDetector interface {
+	Detect(text string) textutil.Language
+}
+
+// LanguageStage detects language of the whole message and sets it to the tokens, tokens written
+// in another script (e.g. english brand in russian message) get language detected by themselves
+type LanguageStage struct {
+	tokenizer.Stage
+
+	detector LanguageDetector
+}
+
+// NewLanguageStage creates a new language detection stage
+func NewLanguageStage(detector LanguageDetector) *LanguageStage {
+	return &LanguageStage{
+		detector: detector,
+	}
+}
+
+// Execute sets language to the tokens and continues to the next stage
+func (s *LanguageStage) Execute(tokens []tokenizer.Token) []tokenizer.Token {
+	start := time.Now()
+
+	words := make([]string, 0, len(tokens))
+	for i := range tokens {
+		words = append(words, tokens[i].SurfaceForm())
+	}
+
+	language := s.detector.Detect(strings.Join(words, " "))
+
+	for i := range tokens {
+		tokens[i].SetLanguage(textutil.ResolveLanguage(tokens[i].SurfaceForm(), language))
+	}
+
+	s.Record(len(tokens), 0, time.Since(start))
+
+	return s.Continue(tokens)
+}
@@ -1,22 +1,49 @@
 package stages
 
 import (
+	"errors"
+
 	"github.com/keenywheels/backend/internal/pkg/tokenizer"
 	"github.com/keenywheels/backend/internal/pkg/tokenizer/metrics"
 )
 
-// NewMetricStage creates a new metric collection stage
-func NewMetricStage(metrics ...metrics.Metric) *tokenizer.Stage {
-	stage := &tokenizer.Stage{}
+var _ = tokenizer.PipelineStage(&MetricStage{})
+
+// MetricStage collects metrics of the tokens
+type MetricStage struct {
+	tokenizer.Stage
+}
+
+// NewMetricStage creates a new metric collection stage, failed metric doesn't prevent
+// collecting other metrics of the token, errors of all metrics are returned
+func NewMetricStage(metrics ...metrics.Metric) *MetricStage {
+	stage := &MetricStage{}
 
 	stage.CallbackFunc = func(token *tokenizer.Token) error {
+		var errs []error
 		for _, m := range metrics {
 			if err := m.Collect(token); err != nil {
-				continue
+				errs = append(errs, err)
 			}
 		}
-		return nil
+		return errors.Join(errs...)
 	}
 
 	return stage
 }
+
+// Streamable reports that the stage is not streamable: targets of the whole message must be shared
+// before collecting metrics, so the stage can't be executed on chunks
+func (s *MetricStage) Streamable() bool {
+	return false
+}
+
+// Execute shares targets of the tokens, so metrics see processed targets of context tokens,
+// then collects metrics and continues to the next stage
+func (s *MetricStage) Execute(tokens []tokenizer.Token) []tokenizer.Token {
+	for i := range tokens {
+		tokens[i].ShareTarget()
+	}
+
+	return s.Stage.Execute(tokens)
+}
@@ -0,0 +1,244 @@
+package stages
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer"
+)
+
+// default values of n-gram stage
+const (
+	DefaultNGramMaxN     = 3
+	DefaultNGramMinCount = 3
+	DefaultNGramMinPMI   = 3.0
+	DefaultNGramWindow   = 24 * time.Hour
+)
+
+var _ = tokenizer.PipelineStage(&NGramStage{})
+
+// NGramStage emits phrases of 2..maxN consecutive not filtered tokens next to the tokens themselves,
+// only collocations are emitted: phrases met in at least minCount messages of the corpus with PMI at least minPMI.
+// A single message is too short to tell a collocation from random neighbours, so both are computed
+// over the message counts of the corpus shared by all pipelines of the factory.
+type NGramStage struct {
+	tokenizer.Stage
+
+	maxN     int
+	minCount int
+	minPMI   float64
+	corpus   *PhraseCorpus
+}
+
+// NewNGramStage creates a new n-gram stage, non-positive values are replaced with defaults
+func NewNGramStage(maxN int, minCount int, minPMI float64, corpus *PhraseCorpus) *NGramStage {
+	if maxN < 2 {
+		maxN = DefaultNGramMaxN
+	}
+
+	if minCount <= 0 {
+		minCount = DefaultNGramMinCount
+	}
+
+	if minPMI <= 0 {
+		minPMI = DefaultNGramMinPMI
+	}
+
+	if corpus == nil {
+		corpus = NewPhraseCorpus(0)
+	}
+
+	return &NGramStage{
+		maxN:     maxN,
+		minCount: minCount,
+		minPMI:   minPMI,
+		corpus:   corpus,
+	}
+}
+
+// phrase represents words of a phrase and positions of its first tokens
+type phrase struct {
+	words     []string
+	positions []int
+}
+
+// Execute appends collocations to the tokens and continues to the next stage
+func (s *NGramStage) Execute(tokens []tokenizer.Token) []tokenizer.Token {
+	var (
+		start   = time.Now()
+		in      = len(tokens)
+		words   = make(map[string]struct{})
+		phrases = make(map[string]*phrase)
+		order   []string // phrases in order of first occurrence, so output is deterministic
+	)
+
+	// filtered tokens (stopwords, punctuation) break phrases
+	for i := range tokens {
+		if tokens[i].IsFiltered() {
+			continue
+		}
+
+		words[tokens[i].Target] = struct{}{}
+
+		phraseWords := make([]string, 0, s.maxN)
+		for j := i; j < len(tokens) && len(phraseWords) < s.maxN; j++ {
+			if tokens[j].IsFiltered() {
+				break
+			}
+
+			phraseWords = append(phraseWords, tokens[j].Target)
+			if len(phraseWords) < 2 {
+				continue
+			}
+
+			target := strings.Join(phraseWords, " ")
+
+			p, ok := phrases[target]
+			if !ok {
+				p = &phrase{words: phraseWords[:len(phraseWords):len(phraseWords)]}
+				phrases[target] = p
+				order = append(order, target)
+			}
+
+			p.positions = append(p.positions, i)
+		}
+	}
+
+	counts := s.corpus.observe(start, words, order)
+
+	for _, target := range order {
+		p := phrases[target]
+		if counts.phrases[target] < s.minCount || counts.pmi(p) < s.minPMI {
+			continue
+		}
+
+		// every occurrence gets the context of its first token
+		for _, pos := range p.positions {
+			first := tokens[pos]
+
+			surfaces := make([]string, 0, len(p.words))
+			for j := pos; j < pos+len(p.words); j++ {
+				surfaces = append(surfaces, tokens[j].SurfaceForm())
+			}
+
+			ngram := tokenizer.Token{
+				Target:    target,
+				Surface:   strings.Join(surfaces, " "),
+				Source:    first.Source,
+				Metadata:  make(map[string]any),
+				Timestamp: first.Timestamp,
+			}
+			ngram.InheritContext(&first)
+			ngram.SetKind(tokenizer.KindPhrase)
+
+			tokens = append(tokens, ngram)
+		}
+	}
+
+	s.Record(in, 0, time.Since(start))
+
+	return s.Continue(tokens)
+}
+
+// corpusCounts represents numbers of messages containing words and phrases
+type corpusCounts struct {
+	messages int
+	words    map[string]int
+	phrases  map[string]int
+}
+
+// newCorpusCounts creates empty counts
+func newCorpusCounts() *corpusCounts {
+	return &corpusCounts{
+		words:   make(map[string]int),
+		phrases: make(map[string]int),
+	}
+}
+
+// pmi returns pointwise mutual information of the phrase words over the messages:
+// log2(p(phrase) / (p(w1) * ... * p(wn))), where p is the share of messages containing the phrase or the word
+func (c *corpusCounts) pmi(p *phrase) float64 {
+	total := float64(c.messages)
+	score := math.Log2(float64(c.phrases[strings.Join(p.words, " ")]) / total)
+
+	for _, word := range p.words {
+		score -= math.Log2(float64(c.words[word]) / total)
+	}
+
+	return score
+}
+
+// PhraseCorpus counts messages containing words and phrases, so the n-gram stage can judge phrases
+// by all recent messages. Counts are kept in memory for the current and the previous window,
+// so they cover from one to two windows of messages and are lost on restart.
+type PhraseCorpus struct {
+	mu sync.Mutex
+
+	window   time.Duration
+	started  time.Time
+	current  *corpusCounts
+	previous *corpusCounts
+}
+
+// NewPhraseCorpus creates a new corpus with the given window, non-positive window is replaced with default
+func NewPhraseCorpus(window time.Duration) *PhraseCorpus {
+	if window <= 0 {
+		window = DefaultNGramWindow
+	}
+
+	return &PhraseCorpus{
+		window:   window,
+		current:  newCorpusCounts(),
+		previous: newCorpusCounts(),
+	}
+}
+
+// observe adds the message to the corpus and returns counts of the message words and phrases in the corpus
+func (c *PhraseCorpus) observe(now time.Time, words map[string]struct{}, phrases []string) *corpusCounts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rotate(now)
+
+	c.current.messages++
+
+	for word := range words {
+		c.current.words[word]++
+	}
+
+	for _, target := range phrases {
+		c.current.phrases[target]++
+	}
+
+	counts := newCorpusCounts()
+	counts.messages = c.current.messages + c.previous.messages
+
+	for word := range words {
+		counts.words[word] = c.current.words[word] + c.previous.words[word]
+	}
+
+	for _, target := range phrases {
+		counts.phrases[target] = c.current.phrases[target] + c.previous.phrases[target]
+	}
+
+	return counts
+}
+
+// rotate starts a new window if the current one is over
+func (c *PhraseCorpus) rotate(now time.Time) {
+	elapsed := now.Sub(c.started)
+	if elapsed < c.window {
+		return
+	}
+
+	// counts older than two windows are dropped too
+	c.previous = c.current
+	if elapsed >= 2*c.window {
+		c.previous = newCorpusCounts()
+	}
+
+	c.current = newCorpusCounts()
+	c.started = now
+}
@@ -0,0 +1,84 @@
+package stages_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/stages"
+)
+
+// phraseTargets runs the message through the pipeline and returns targets of the emitted phrases
+func phraseTargets(t *testing.T, factory *stages.PipelineFactory, text string) []string {
+	t.Helper()
+
+	pipeline, _ := factory.Build()
+
+	tokens, err := pipeline.Run(factory.Tokens(text))
+	if err != nil {
+		t.Fatalf("failed to run pipeline: %v", err)
+	}
+
+	var phrases []string
+
+	for _, token := range tokens {
+		if token.Kind() == tokenizer.KindPhrase {
+			phrases = append(phrases, token.Target)
+		}
+	}
+
+	return phrases
+}
+
+func TestNGramStage(t *testing.T) {
+	factory, err := stages.NewPipelineFactory(stages.PipelineConfig{Stages: []stages.StageConfig{
+		{Name: stages.StageNGram},
+	}})
+	if err != nil {
+		t.Fatalf("failed to create pipeline factory: %v", err)
+	}
+
+	vocabulary := make([]string, 40)
+	for i := range vocabulary {
+		vocabulary[i] = fmt.Sprintf("слово%d", i)
+	}
+
+	// every pair of words is met only once in the message, so it looks like a collocation within the message
+	if phrases := phraseTargets(t, factory, strings.Join(vocabulary, " ")); len(phrases) != 0 {
+		t.Fatalf("expected no phrases in message of unrelated words, got %v", phrases)
+	}
+
+	// words of the vocabulary are neighbours by chance, so no phrase is frequent enough for its words
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	for i := range 200 {
+		words := make([]string, 20)
+		for j := range words {
+			words[j] = vocabulary[rnd.IntN(len(vocabulary))]
+		}
+
+		if phrases := phraseTargets(t, factory, strings.Join(words, " ")); len(phrases) != 0 {
+			t.Fatalf("expected no phrases in random message %d, got %v", i, phrases)
+		}
+	}
+
+	// words of the collocation are met together only, phrase is emitted since min_count messages
+	for i := range stages.DefaultNGramMinCount {
+		text := fmt.Sprintf("%s искусственный интеллект %s", vocabulary[i], vocabulary[i+1])
+
+		phrases := phraseTargets(t, factory, text)
+		if i+1 < stages.DefaultNGramMinCount {
+			if len(phrases) != 0 {
+				t.Fatalf("expected no phrases before min count, got %v", phrases)
+			}
+
+			continue
+		}
+
+		if len(phrases) != 1 || phrases[0] != "искусственный интеллект" {
+			t.Fatalf("expected collocation, got %v", phrases)
+		}
+	}
+}
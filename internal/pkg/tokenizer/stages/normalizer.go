@@ -1,6 +1,7 @@
 package stages
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 
@@ -8,6 +9,15 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
+// Normalization modes
+const (
+	// NormalizerModeLetters keeps only letters of the token
+	NormalizerModeLetters = "letters"
+	// NormalizerModeAlnum keeps letters, digits and separators inside the token, so product codes,
+	// model numbers and versions are kept as is
+	NormalizerModeAlnum = "alnum"
+)
+
 // NewNormalizerStage creates a new normalization stage that normalizes token strings.
 func NewNormalizerStage() *tokenizer.Stage {
 	stage := &tokenizer.Stage{}
@@ -20,6 +30,32 @@ func NewNormalizerStage() *tokenizer.Stage {
 	return stage
 }
 
+// NewAlnumNormalizerStage creates a new normalization stage that keeps digits and inner separators
+// of the tokens and marks them with their kind (word, code, version or number).
+func NewAlnumNormalizerStage() *tokenizer.Stage {
+	stage := &tokenizer.Stage{}
+
+	stage.CallbackFunc = func(token *tokenizer.Token) error {
+		token.Target = normalizeAlnumString(token.Target)
+		token.SetKind(detectKind(token.Target))
+		return nil
+	}
+
+	return stage
+}
+
+// NewNormalizerStageWithMode creates a new normalization stage with the given mode.
+func NewNormalizerStageWithMode(mode string) (*tokenizer.Stage, error) {
+	switch mode {
+	case "", NormalizerModeLetters:
+		return NewNormalizerStage(), nil
+	case NormalizerModeAlnum:
+		return NewAlnumNormalizerStage(), nil
+	default:
+		return nil, fmt.Errorf("unknown normalizer mode %q", mode)
+	}
+}
+
 // normalizeString normalizes the input string by applying Unicode NFC normalization,
 func normalizeString(str string) string {
 	str = norm.NFC.String(str)
@@ -36,3 +72,95 @@ func normalizeString(str string) string {
 
 	return builder.String()
 }
+
+// normalizeAlnumString normalizes the input string keeping letters and digits, hyphens and dots
+// are kept only between letters or digits, e.g. "«COVID-19»," -> "covid-19", "v2.0." -> "v2.0"
+func normalizeAlnumString(str string) string {
+	str = norm.NFC.String(str)
+
+	var (
+		builder   = strings.Builder{}
+		separator rune // separator waiting for the next letter or digit
+	)
+
+	builder.Grow(len(str))
+
+	for _, r := range str {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if separator != 0 && builder.Len() > 0 {
+				builder.WriteRune(separator)
+			}
+
+			separator = 0
+			builder.WriteRune(unicode.ToLower(r))
+		case isSeparator(r):
+			// the first separator in a row wins, e.g. "a-.b" -> "a-b"
+			if separator == 0 {
+				separator = normalizeSeparator(r)
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// isSeparator checks if the rune can separate parts of a compound token
+func isSeparator(r rune) bool {
+	return r == '.' || r == '_' || unicode.Is(unicode.Dash, r)
+}
+
+// normalizeSeparator replaces all kinds of dashes with hyphen-minus
+func normalizeSeparator(r rune) rune {
+	if unicode.Is(unicode.Dash, r) {
+		return '-'
+	}
+
+	return r
+}
+
+// detectKind detects kind of the normalized token
+func detectKind(str string) string {
+	var letters, digits, dots int
+
+	for _, r := range str {
+		switch {
+		case unicode.IsLetter(r):
+			letters++
+		case unicode.IsDigit(r):
+			digits++
+		case r == '.':
+			dots++
+		}
+	}
+
+	switch {
+	case digits == 0:
+		return tokenizer.KindWord
+	case dots > 0 && isVersion(str):
+		return tokenizer.KindVersion
+	case letters > 0:
+		return tokenizer.KindCode
+	default:
+		return tokenizer.KindNumber
+	}
+}
+
+// isVersion checks if the token is a dotted version, e.g. "1.2.3" or "v2.0"
+func isVersion(str string) bool {
+	str = strings.TrimPrefix(str, "v")
+
+	for _, part := range strings.Split(str, ".") {
+		if part == "" {
+			return false
+		}
+
+		for _, r := range part {
+			if !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
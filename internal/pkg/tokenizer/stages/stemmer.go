@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/keenywheels/backend/internal/pkg/tokenizer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
 )
 
 // Stemmer defines the interface for stemming tokens.
@@ -11,12 +12,34 @@ type Stemmer interface {
 	Stem(token string) (string, error)
 }
 
+// LanguageStemmer defines the interface for stemming tokens of the known language,
+// it is used if language of the token is set by language stage.
+type LanguageStemmer interface {
+	StemLanguage(token string, language textutil.Language) (string, error)
+}
+
 // NewStemmerStage creates a new stemming stage that applies the provided stemmer to tokens.
 func NewStemmerStage(stemmer Stemmer) *tokenizer.Stage {
 	stage := &tokenizer.Stage{}
+	languageStemmer, _ := stemmer.(LanguageStemmer)
 
 	stage.CallbackFunc = func(token *tokenizer.Token) error {
-		stemmedToken, err := stemmer.Stem(token.Target)
+		// codes and versions are kept as is
+		if token.Kind() != tokenizer.KindWord {
+			return nil
+		}
+
+		var (
+			stemmedToken string
+			err          error
+		)
+
+		if language := token.Language(); language != "" && languageStemmer != nil {
+			stemmedToken, err = languageStemmer.StemLanguage(token.Target, language)
+		} else {
+			stemmedToken, err = stemmer.Stem(token.Target)
+		}
+
 		if err != nil {
 			return fmt.Errorf("stemmer failed: %w", err)
 		}
@@ -0,0 +1,140 @@
+package stages_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/stemmer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/stages"
+)
+
+// stemTargets runs the message through the pipeline of the stages and returns targets of the tokens
+func stemTargets(t *testing.T, text string, cfg ...stages.StageConfig) []string {
+	t.Helper()
+
+	factory, err := stages.NewPipelineFactory(stages.PipelineConfig{Stages: cfg})
+	if err != nil {
+		t.Fatalf("failed to create pipeline factory: %v", err)
+	}
+
+	pipeline, _ := factory.Build()
+
+	tokens, err := pipeline.Run(factory.Tokens(text))
+	if err != nil {
+		t.Fatalf("failed to run pipeline: %v", err)
+	}
+
+	targets := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		targets = append(targets, token.Target)
+	}
+
+	return targets
+}
+
+func TestStemmerStageMessageLanguage(t *testing.T) {
+	var (
+		language = stages.StageConfig{Name: stages.StageLanguage}
+		stem     = stages.StageConfig{Name: stages.StageStemmer, Languages: []string{"russian", "english"}}
+	)
+
+	tests := []struct {
+		name     string
+		text     string
+		cfg      []stages.StageConfig
+		expected []string
+	}{
+		{
+			// words without ukrainian letters are not stemmed as russian ones in ukrainian message
+			name:     "ukrainian message",
+			text:     "ціни на пальне знову зросли",
+			cfg:      []stages.StageConfig{language, stem},
+			expected: []string{"ціни", "на", "пальне", "знову", "зросли"},
+		},
+		{
+			name:     "russian message",
+			text:     "цены на бензин снова выросли",
+			cfg:      []stages.StageConfig{language, stem},
+			expected: []string{"цен", "на", "бензин", "снов", "выросл"},
+		},
+		{
+			// without language stage the default language is used for words of its script
+			name:     "default language",
+			text:     "знову новые phones",
+			cfg:      []stages.StageConfig{stem},
+			expected: []string{"знов", "нов", "phone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets := stemTargets(t, tt.text, tt.cfg...)
+			if len(targets) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, targets)
+			}
+
+			for i := range targets {
+				if targets[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, targets)
+				}
+			}
+		})
+	}
+}
+
+func TestStemmerStageUnsupportedLanguage(t *testing.T) {
+	for _, language := range []string{"ukrainian", "kazakh", "german"} {
+		_, err := stages.NewPipelineFactory(stages.PipelineConfig{Stages: []stages.StageConfig{
+			{Name: stages.StageStemmer, Languages: []string{"russian", language}},
+		}})
+		if err == nil {
+			t.Errorf("expected error of %s stemmer", language)
+		}
+	}
+}
+
+func TestStemDefaultLanguage(t *testing.T) {
+	stm := stemmer.New(textutil.English, textutil.English, textutil.Russian)
+
+	// english is default, so english word is not mistaken for a word of another latin language
+	for token, expected := range map[string]string{"running": "run", "телефоны": "телефон"} {
+		got, err := stm.Stem(token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != expected {
+			t.Errorf("Stem(%q) = %q, expected %q", token, got, expected)
+		}
+	}
+}
+
+func TestStemmerStageDictionaryFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dict.opcorpora.txt")
+
+	dict := "1\nКРИПТОВАЛЮТА\tNOUN,inan,femn sing,nomn\nКРИПТОВАЛЮТЫ\tNOUN,inan,femn sing,gent\n"
+	if err := os.WriteFile(path, []byte(dict), 0o600); err != nil {
+		t.Fatalf("failed to write dictionary: %v", err)
+	}
+
+	targets := stemTargets(t, "биржи криптовалюты новости", stages.StageConfig{
+		Name:            stages.StageStemmer,
+		Languages:       []string{"russian"},
+		Lemmatize:       []string{"russian"},
+		DictionaryFiles: []string{path},
+	})
+
+	// word missing in the dictionaries is stemmed
+	expected := []string{"бирж", "криптовалюта", "новость"}
+	if len(targets) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, targets)
+	}
+
+	for i := range targets {
+		if targets[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, targets)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package tokenizer
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrorPolicy defines what a stage does with a token its callback failed on.
+type ErrorPolicy string
+
+// Available error policies of the stage
+const (
+	// ErrorPolicyIgnore keeps the token as it is and continues with the next token
+	ErrorPolicyIgnore ErrorPolicy = "ignore"
+	// ErrorPolicyFilter marks the token as filtered and continues with the next token
+	ErrorPolicyFilter ErrorPolicy = "filter"
+	// ErrorPolicyAbort stops the pipeline run, the error is returned by Pipeline.Run
+	ErrorPolicyAbort ErrorPolicy = "abort"
+)
+
+// ParseErrorPolicy parses the error policy by its name, ignore policy is used if name is empty.
+func ParseErrorPolicy(name string) (ErrorPolicy, error) {
+	switch policy := ErrorPolicy(name); policy {
+	case "":
+		return ErrorPolicyIgnore, nil
+	case ErrorPolicyIgnore, ErrorPolicyFilter, ErrorPolicyAbort:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown error policy %q", name)
+	}
+}
+
+// StageStats represents what the stage did during a pipeline run.
+type StageStats struct {
+	Name     string
+	In       int           // number of tokens passed to the stage
+	Filtered int           // number of tokens filtered by the stage
+	Errors   int           // number of tokens the stage callback failed on
+	Elapsed  time.Duration // time spent by the stage itself, summed over chunks in streaming pipeline
+}
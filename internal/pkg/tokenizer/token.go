@@ -1,8 +1,12 @@
 package tokenizer
 
 import (
+	"slices"
 	"strings"
 	"time"
+	"unicode"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/textutil"
 )
 
 const (
@@ -10,17 +14,64 @@ const (
 	DefaultContextWindow = 5
 )
 
-// Token represents a token with its context and metadata
+// Kinds of tokens, kind is set by normalizer and used by the next stages
+const (
+	// KindWord is a regular word, e.g. "привет" or "wi-fi"
+	KindWord = "word"
+	// KindCode is a product or model code with letters and digits, e.g. "rtx4090", "5g", "covid-19"
+	KindCode = "code"
+	// KindVersion is a dotted version, e.g. "1.2.3" or "v2.0"
+	KindVersion = "version"
+	// KindNumber is a plain number, e.g. "2024"
+	KindNumber = "number"
+	// KindPhrase is a multi-word phrase of stemmed words joined with space, e.g. "искусствен интеллект"
+	KindPhrase = "phrase"
+)
+
+// Token represents a token with its context and metadata,
+// Context is empty for tokens with lazy context, ContextTokens should be used to get it
 type Token struct {
 	Target    string
+	Surface   string // original word as it was in the text
 	Context   []Token
 	Source    string
 	Metadata  map[string]any
 	Timestamp time.Time
+
+	// lazy context: tokens of the message as they were before pipeline and position of the token
+	source   []Token
+	window   int
+	position int
 }
 
 // GetTokens tokenizes the input text and returns a slice of Tokens with context
 func GetTokens(text string, tokenConfig *TokenConfig) []Token {
+	tokens := splitTokens(text, tokenConfig)
+
+	collectContext(tokens, tokenConfig)
+
+	return tokens
+}
+
+// GetLazyTokens tokenizes the input text and returns a slice of Tokens with lazy context,
+// tokens of the message are copied once instead of copying the context window for every token
+func GetLazyTokens(text string, tokenConfig *TokenConfig) []Token {
+	tokens := splitTokens(text, tokenConfig)
+
+	// copies share metadata with the tokens as copies in eager context do
+	source := slices.Clone(tokens)
+
+	for i := range tokens {
+		tokens[i].source = source
+		tokens[i].window = tokenConfig.ContextWindow
+		tokens[i].position = i
+	}
+
+	return tokens
+}
+
+// splitTokens splits the input text into tokens without context
+func splitTokens(text string, tokenConfig *TokenConfig) []Token {
 	now := time.Now()
 
 	words := strings.Fields(text)
@@ -29,17 +80,37 @@ func GetTokens(text string, tokenConfig *TokenConfig) []Token {
 	for i, word := range words {
 		tokens[i] = Token{
 			Target:    word,
+			Surface:   word,
 			Source:    tokenConfig.TokenSource,
 			Metadata:  make(map[string]any),
 			Timestamp: now,
 		}
 	}
 
-	collectContext(tokens, tokenConfig)
-
 	return tokens
 }
 
+// ContextTokens returns tokens of the context window as they were before pipeline, the token itself included,
+// lazy context is computed on demand and must not be modified
+func (t *Token) ContextTokens() []Token {
+	if t.source == nil {
+		return t.Context
+	}
+
+	start := max(0, t.position-t.window)
+	end := min(len(t.source), t.position+t.window+1)
+
+	return t.source[start:end:end]
+}
+
+// InheritContext makes the token share the context of another token, e.g. phrase gets context of its first word
+func (t *Token) InheritContext(other *Token) {
+	t.Context = other.Context
+	t.source = other.source
+	t.window = other.window
+	t.position = other.position
+}
+
 // IsFiltered checks if the token is marked as filtered in its metadata
 func (t *Token) IsFiltered() bool {
 	if t.Metadata == nil {
@@ -59,6 +130,77 @@ func (t *Token) Filter() {
 	t.Metadata["filtered"] = true
 }
 
+// SurfaceForm returns the original word without surrounding punctuation, e.g. "«iPhone15»," -> "iPhone15"
+func (t *Token) SurfaceForm() string {
+	return strings.TrimFunc(t.Surface, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Kind returns the kind of the token, tokens without kind are words
+func (t *Token) Kind() string {
+	if t.Metadata == nil {
+		return KindWord
+	}
+
+	kind, ok := t.Metadata["kind"].(string)
+	if !ok {
+		return KindWord
+	}
+
+	return kind
+}
+
+// SetKind sets the kind of the token in its metadata
+func (t *Token) SetKind(kind string) {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]any)
+	}
+	t.Metadata["kind"] = kind
+}
+
+// ShareTarget saves the current target in metadata, metadata is shared with copies of the token
+// in contexts of other tokens, so they see the target processed by the previous stages
+func (t *Token) ShareTarget() {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]any)
+	}
+	t.Metadata["target"] = t.Target
+}
+
+// SharedTarget returns the target saved by ShareTarget, the original target if it was not saved
+func (t *Token) SharedTarget() string {
+	if t.Metadata == nil {
+		return t.Target
+	}
+
+	target, ok := t.Metadata["target"].(string)
+	if !ok {
+		return t.Target
+	}
+
+	return target
+}
+
+// Language returns the language of the token set by language stage, empty if not set
+func (t *Token) Language() textutil.Language {
+	if t.Metadata == nil {
+		return ""
+	}
+
+	language, _ := t.Metadata["language"].(textutil.Language)
+
+	return language
+}
+
+// SetLanguage sets the language of the token in its metadata
+func (t *Token) SetLanguage(language textutil.Language) {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]any)
+	}
+	t.Metadata["language"] = language
+}
+
 // collectContext populates the Context field for each token based on the context window
 func collectContext(tokens []Token, tokenConfig *TokenConfig) {
 	n := len(tokens)
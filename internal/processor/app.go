@@ -2,16 +2,18 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os/signal"
 	"syscall"
 
-	"github.com/keenywheels/backend/internal/pkg/client/llm"
 	"github.com/keenywheels/backend/internal/pkg/consumer/kafka"
+	producer "github.com/keenywheels/backend/internal/pkg/producer/kafka"
 	"github.com/keenywheels/backend/internal/processor/delivery/broker"
 	"github.com/keenywheels/backend/internal/processor/repository"
 	"github.com/keenywheels/backend/internal/processor/service"
+	"github.com/keenywheels/backend/pkg/ctxutils"
 	"github.com/keenywheels/backend/pkg/logger"
 	"github.com/keenywheels/backend/pkg/logger/zap"
 	"github.com/keenywheels/backend/pkg/mailer/smtp"
@@ -19,6 +21,10 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// replayGroupSuffix is added to consumer group id when replaying dead-letter topics,
+// so the replay does not interfere with offsets of the regular consumer
+const replayGroupSuffix = "_dlq_replay"
+
 // App represent app environment
 type App struct {
 	opts *Options
@@ -63,14 +69,30 @@ func (app *App) Run() error {
 	}
 	defer db.Close()
 
-	// create llm client
-	llm := llm.NewClient(&cfg.App.Clients.LLM)
+	// create sentiment analysis client
+	llm, closeLLM, err := app.getSentimentClient()
+	if err != nil {
+		return fmt.Errorf("failed to create sentiment client: %w", err)
+	}
+	defer closeLLM()
 
 	// create service layer
 	mailer := smtp.New(&cfg.App.SMTPCfg)
 
 	repo := repository.New(db)
-	service := service.New(repo, llm, mailer)
+	service, err := service.New(repo, llm, mailer, cfg.App.Service)
+	if err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+
+	// create kafka producer (used to put failed messages into retry and dead-letter topics)
+	kafkaProducer, err := producer.New(cfg.KafkaCfg.Brokers, producer.Config{
+		MaxRetry: cfg.KafkaCfg.MaxRetry,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+	defer kafkaProducer.Close()
 
 	// create broker
 	brokerOpts := []broker.Option{
@@ -80,18 +102,43 @@ func (app *App) Run() error {
 	brokerCfg := broker.Config{
 		WorkerCount:   cfg.App.Processor.WorkersCount,
 		MaxRetryCount: cfg.App.Processor.MaxRetries,
-		RetryDelay:    cfg.App.Processor.RetryDelay,
+		RetryDelays:   cfg.App.Processor.RetryDelays,
+		OrderedKeys:   cfg.App.Processor.OrderedKeys,
+		DrainTimeout:  cfg.App.Processor.DrainTimeout,
+		QueueSize:     cfg.App.Processor.QueueSize,
+		Pools:         app.poolConfigs(),
+	}
+
+	b, err := broker.New(brokerCfg, kafkaProducer, brokerOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create broker: %w", err)
 	}
 
-	b := broker.New(brokerCfg, service, broker.Topics{
-		ScraperData:   cfg.KafkaCfg.Topics.ScraperData,
-		Notifications: cfg.KafkaCfg.Topics.Notifications,
-	}, brokerOpts...)
+	// register topic handlers
+	if err := app.registerHandlers(b, service); err != nil {
+		return fmt.Errorf("failed to register topic handlers: %w", err)
+	}
+
+	// choose topics and consumer group
+	groupID := cfg.KafkaCfg.GroupID
+	topics := b.Topics()
+
+	// retry topics are consumed only by the regular consumer group, even for replayed messages
+	if !app.opts.ReplayDLQ {
+		topics = append(topics, b.RetryTopics()...)
+	} else {
+		groupID = fmt.Sprintf("%s%s", groupID, replayGroupSuffix)
+		topics = app.deadLetterTopics()
+
+		if len(topics) == 0 {
+			return errors.New("no dead-letter topics configured to replay")
+		}
+	}
 
 	// create kafka consumer
 	kafkaConsumer, err := kafka.New(
 		cfg.KafkaCfg.Brokers,
-		cfg.KafkaCfg.GroupID,
+		groupID,
 		kafka.Config{},
 		kafka.WithLogger(app.logger),
 	)
@@ -105,12 +152,22 @@ func (app *App) Run() error {
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	// start consuming
-	topics := []string{
-		cfg.KafkaCfg.Topics.ScraperData,   // topic with data from scraped sites
-		cfg.KafkaCfg.Topics.Notifications, // topic with notifications
+	// run sentiment backfill scheduler (replay consumer relies on the regular one)
+	if !app.opts.ReplayDLQ {
+		app.logger.Infof("starting service scheduler with cfg=%+v", cfg.App.Service.Backfill)
+
+		if err := service.StartScheduler(ctxutils.SetLogger(ctx, app.logger)); err != nil {
+			return fmt.Errorf("failed to start service scheduler: %w", err)
+		}
+
+		g.Go(func() error {
+			<-ctx.Done()
+			app.logger.Infof("shutting down service scheduler...")
+			return service.CloseScheduler()
+		})
 	}
 
+	// start consuming
 	g.Go(func() error {
 		app.logger.Infof("starting kafka consumer for topics: %v", topics)
 
@@ -131,6 +188,86 @@ func (app *App) Run() error {
 	return nil
 }
 
+// registerHandlers registers handlers for all topics consumed by the processor
+func (app *App) registerHandlers(b *broker.Broker, service *service.Service) error {
+	var (
+		topics    = app.cfg.KafkaCfg.Topics
+		dlqTopics = app.cfg.KafkaCfg.DeadLetterTopics
+		handlers  = app.cfg.App.Processor.Handlers
+	)
+
+	// messages which fail during replay stay in dead-letter topic, so they are not sent there again
+	if app.opts.ReplayDLQ {
+		dlqTopics = KafkaTopics{}
+	}
+
+	// topic with data from scraped sites
+	if err := b.Register(
+		topics.ScraperData,
+		service.TokenizeMessage,
+		handlerConfig(handlers.ScraperData, dlqTopics.ScraperData),
+	); err != nil {
+		return err
+	}
+
+	// topic with notifications
+	if err := b.Register(
+		topics.Notifications,
+		service.NotifyUser,
+		handlerConfig(handlers.Notifications, dlqTopics.Notifications),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handlerConfig converts handler config to the broker one
+func handlerConfig(cfg HandlerConfig, deadLetterTopic string) broker.HandlerConfig {
+	return broker.HandlerConfig{
+		MaxRetry:        cfg.MaxRetries,
+		RetryDelays:     cfg.RetryDelays,
+		Timeout:         cfg.Timeout,
+		Concurrency:     cfg.Concurrency,
+		DeadLetterTopic: deadLetterTopic,
+	}
+}
+
+// poolConfigs returns worker pool settings of all topics, so expensive topics do not starve cheap ones
+func (app *App) poolConfigs() map[string]broker.PoolConfig {
+	var (
+		topics   = app.cfg.KafkaCfg.Topics
+		handlers = app.cfg.App.Processor.Handlers
+	)
+
+	return map[string]broker.PoolConfig{
+		topics.ScraperData: {
+			Workers:   handlers.ScraperData.Workers,
+			QueueSize: handlers.ScraperData.QueueSize,
+		},
+		topics.Notifications: {
+			Workers:   handlers.Notifications.Workers,
+			QueueSize: handlers.Notifications.QueueSize,
+		},
+	}
+}
+
+// deadLetterTopics returns all configured dead-letter topics
+func (app *App) deadLetterTopics() []string {
+	var topics []string
+
+	for _, topic := range []string{
+		app.cfg.KafkaCfg.DeadLetterTopics.ScraperData,
+		app.cfg.KafkaCfg.DeadLetterTopics.Notifications,
+	} {
+		if topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+
+	return topics
+}
+
 // initLogger create new Logger based on config
 func (app *App) initLogger() {
 	logCfg := app.cfg.App.LoggerCfg
@@ -6,13 +6,30 @@ import (
 	"time"
 
 	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	llmbreaker "github.com/keenywheels/backend/internal/pkg/client/llm/breaker"
+	llmcache "github.com/keenywheels/backend/internal/pkg/client/llm/cache"
+	"github.com/keenywheels/backend/internal/pkg/client/llm/lexicon"
+	"github.com/keenywheels/backend/internal/pkg/client/llm/openai"
+	"github.com/keenywheels/backend/internal/processor/service"
 	"github.com/keenywheels/backend/pkg/mailer/smtp"
+	"github.com/keenywheels/backend/pkg/redis"
 	"github.com/spf13/viper"
 )
 
+// SentimentProviderConfig struct for sentiment analysis provider config
+type SentimentProviderConfig struct {
+	Provider string `mapstructure:"provider"`
+	Fallback string `mapstructure:"fallback"`
+}
+
 // ClientsConfig struct for external clients config
 type ClientsConfig struct {
-	LLM llm.Config `mapstructure:"llm"`
+	Sentiment  SentimentProviderConfig `mapstructure:"sentiment"`
+	Lexicon    lexicon.Config          `mapstructure:"lexicon"`
+	LLM        llm.Config              `mapstructure:"llm"`
+	OpenAI     openai.Config           `mapstructure:"openai"`
+	LLMCache   llmcache.Config         `mapstructure:"llm_cache"`
+	LLMBreaker llmbreaker.Config       `mapstructure:"llm_breaker"`
 }
 
 // LoggerConfig struct for logger config
@@ -26,11 +43,31 @@ type LoggerConfig struct {
 	MaxLogAge     int    `mapstructure:"max_log_age"`
 }
 
+// HandlerConfig struct for topic handler config, zero values are replaced with processor defaults
+type HandlerConfig struct {
+	Workers     int             `mapstructure:"workers"`
+	QueueSize   int             `mapstructure:"queue_size"`
+	MaxRetries  int             `mapstructure:"max_retries"`
+	RetryDelays []time.Duration `mapstructure:"retry_delays"`
+	Timeout     time.Duration   `mapstructure:"timeout"`
+	Concurrency int             `mapstructure:"concurrency"`
+}
+
+// HandlersConfig struct for configs of all topic handlers
+type HandlersConfig struct {
+	ScraperData   HandlerConfig `mapstructure:"scraper_data"`
+	Notifications HandlerConfig `mapstructure:"notifications"`
+}
+
 // ProcessorConfig struct for processor config
 type ProcessorConfig struct {
-	WorkersCount int           `mapstructure:"workers_count"`
-	MaxRetries   int           `mapstructure:"max_retries"`
-	RetryDelay   time.Duration `mapstructure:"retry_delay"`
+	WorkersCount int             `mapstructure:"workers_count"`
+	QueueSize    int             `mapstructure:"queue_size"`
+	MaxRetries   int             `mapstructure:"max_retries"`
+	RetryDelays  []time.Duration `mapstructure:"retry_delays"`
+	OrderedKeys  bool            `mapstructure:"ordered_keys"`
+	DrainTimeout time.Duration   `mapstructure:"drain_timeout"`
+	Handlers     HandlersConfig  `mapstructure:"handlers"`
 }
 
 // PostgresConfig struct for postgres config
@@ -62,7 +99,9 @@ func (pc PostgresConfig) DSN() string {
 type AppConfig struct {
 	Clients   ClientsConfig   `mapstructure:"clients"`
 	Processor ProcessorConfig `mapstructure:"processor"`
+	Service   service.Config  `mapstructure:"service"`
 	Postgres  PostgresConfig  `mapstructure:"postgres"`
+	Redis     redis.Config    `mapstructure:"redis"`
 	LoggerCfg LoggerConfig    `mapstructure:"logger"`
 	SMTPCfg   smtp.Config     `mapstructure:"smtp"`
 }
@@ -75,9 +114,11 @@ type KafkaTopics struct {
 
 // KafkaConfig contains Kafka configuration
 type KafkaConfig struct {
-	GroupID string      `mapstructure:"group_id"`
-	Brokers []string    `mapstructure:"brokers"`
-	Topics  KafkaTopics `mapstructure:"topics"`
+	GroupID          string      `mapstructure:"group_id"`
+	MaxRetry         int         `mapstructure:"max_retry"`
+	Brokers          []string    `mapstructure:"brokers"`
+	Topics           KafkaTopics `mapstructure:"topics"`
+	DeadLetterTopics KafkaTopics `mapstructure:"dead_letter_topics"`
 }
 
 // Config is the main configuration struct
@@ -2,71 +2,93 @@ package broker
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/keenywheels/backend/pkg/logger"
 	"github.com/keenywheels/backend/pkg/logger/zap"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // default values
 const (
-	defaultWorkerCount = 5
-	defaultMaxRetry    = 3
-	defaultRetryDelay  = 5 * time.Second
+	defaultWorkerCount  = 5
+	defaultMaxRetry     = 3
+	defaultDrainTimeout = 10 * time.Second
 )
 
+// defaultRetryDelays delays of retry topics used if not provided in config
+var defaultRetryDelays = []time.Duration{
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+}
+
 // tasks statuses
 const (
-	statusSuccess = "success"
-	statusFailed  = "failed"
+	statusSuccess      = "success"
+	statusFailed       = "failed"
+	statusRetried      = "retried"
+	statusDeadLettered = "dead_lettered"
+	statusAbandoned    = "abandoned"
 )
 
 // message represents a message in queue
 type message struct {
-	msg    *sarama.ConsumerMessage
-	id     string
-	retry  int
-	status string
-}
-
-// IService defines the interface for the service layer of processor
-type IService interface {
-	TokenizeMessage(ctx context.Context, message string) error
-	NotifyUser(ctx context.Context, message string) error
+	ctx     context.Context // context of the claim, canceled when partition is revoked
+	msg     *sarama.ConsumerMessage
+	offsets *partitionOffsets
+	id      string
+	retry   int
+	status  string
 }
 
-// Topics holds the topic names
-type Topics struct {
-	ScraperData   string
-	Notifications string
+// IProducer defines the interface for producing messages back to kafka
+type IProducer interface {
+	Produce(msg *sarama.ProducerMessage) error
 }
 
 // Broker struct for message broker
 type Broker struct {
-	l       logger.Logger
-	service IService
-	topics  Topics
+	l        logger.Logger
+	producer IProducer
 
-	// settings for message handling
+	// handlers maps source topic to its handler
+	handlers map[string]*topicHandler
+
+	// settings for message handling (used as defaults for handlers)
 	workerCount  int
+	queueSize    int
 	maxRetry     int
-	retryDelay   time.Duration
-	messageQueue chan message
-	ackQueue     chan message
-	wg           sync.WaitGroup
+	retryDelays  []time.Duration
+	orderedKeys  bool
+	drainTimeout time.Duration
+
+	// pools holds worker pool settings by source topic
+	pools map[string]PoolConfig
+
+	meterProvider metric.MeterProvider
+
+	// current consumer group session (and registered handlers)
+	mu   sync.RWMutex
+	sess *consumerSession
 }
 
 // New creates a new Broker instance
-func New(cfg Config, service IService, topics Topics, opts ...Option) *Broker {
+func New(cfg Config, producer IProducer, opts ...Option) (*Broker, error) {
 	b := &Broker{
-		l:           zap.New(),
-		service:     service,
-		topics:      topics,
-		workerCount: defaultWorkerCount,
-		maxRetry:    defaultMaxRetry,
-		retryDelay:  defaultRetryDelay,
+		l:             zap.New(),
+		producer:      producer,
+		handlers:      make(map[string]*topicHandler),
+		workerCount:   defaultWorkerCount,
+		maxRetry:      defaultMaxRetry,
+		retryDelays:   defaultRetryDelays,
+		drainTimeout:  defaultDrainTimeout,
+		pools:         cfg.Pools,
+		meterProvider: otel.GetMeterProvider(),
 	}
 
 	// apply options
@@ -83,13 +105,24 @@ func New(cfg Config, service IService, topics Topics, opts ...Option) *Broker {
 		b.maxRetry = cfg.MaxRetryCount
 	}
 
-	if cfg.RetryDelay > 0 {
-		b.retryDelay = cfg.RetryDelay
+	if len(cfg.RetryDelays) > 0 {
+		b.retryDelays = cfg.RetryDelays
+	}
+
+	if cfg.DrainTimeout > 0 {
+		b.drainTimeout = cfg.DrainTimeout
 	}
 
-	// initialize queues
-	b.messageQueue = make(chan message, b.workerCount*2)
-	b.ackQueue = make(chan message, b.workerCount*2)
+	if cfg.QueueSize > 0 {
+		b.queueSize = cfg.QueueSize
+	}
+
+	b.orderedKeys = cfg.OrderedKeys
+
+	// register metrics
+	if err := b.registerMetrics(); err != nil {
+		return nil, fmt.Errorf("failed to register broker metrics: %w", err)
+	}
 
-	return b
+	return b, nil
 }
@@ -2,9 +2,27 @@ package broker
 
 import "time"
 
-// Config holds the configuration for the Broker
+// Config holds the configuration for the Broker, worker and retry settings are defaults for handlers
 type Config struct {
 	WorkerCount   int
 	MaxRetryCount int
-	RetryDelay    time.Duration
+	RetryDelays   []time.Duration
+
+	// DrainTimeout is how long in-flight messages of revoked partition are waited before being abandoned
+	DrainTimeout time.Duration
+
+	// OrderedKeys enables processing of messages with the same key in the order they were received
+	OrderedKeys bool
+
+	// QueueSize is the default queue depth of worker pools, twice the number of workers if zero
+	QueueSize int
+
+	// Pools holds settings of worker pools by source topic, missing values are replaced with defaults
+	Pools map[string]PoolConfig
+}
+
+// PoolConfig holds the settings of the worker pool of a single topic
+type PoolConfig struct {
+	Workers   int
+	QueueSize int
 }
@@ -3,81 +3,177 @@ package broker
 import (
 	"context"
 	"errors"
+	"hash/fnv"
 
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
 	"github.com/keenywheels/backend/pkg/ctxutils"
-	"golang.org/x/sync/errgroup"
 )
 
-// Setup prepares the broker for message consumption
+// Setup prepares the broker for message consumption, called at the beginning of every session
 func (b *Broker) Setup(session sarama.ConsumerGroupSession) error {
-	for i := 0; i < b.workerCount; i++ {
-		b.wg.Add(1)
-		go b.worker(ctxutils.SetLogger(session.Context(), b.l), i)
-	}
+	sess := b.newConsumerSession(session)
+	b.start(sess)
+
+	b.mu.Lock()
+	b.sess = sess
+	b.mu.Unlock()
+
+	b.l.Infof("consumer session %d started, claims: %v", session.GenerationID(), session.Claims())
 
 	return nil
 }
 
-// Cleanup cleans up resources after message consumption
+// Cleanup cleans up resources after message consumption, called after all claims of the session are done
 func (b *Broker) Cleanup(session sarama.ConsumerGroupSession) error {
-	close(b.messageQueue)
-	close(b.ackQueue)
-	b.wg.Wait()
+	b.mu.Lock()
+	sess := b.sess
+	b.sess = nil
+	b.mu.Unlock()
+
+	if sess != nil {
+		sess.stop()
+	}
+
+	b.l.Infof("consumer session %d finished", session.GenerationID())
 
 	return nil
 }
 
 // ConsumeClaim get messages from kafka
 func (b *Broker) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	gr, ctx := errgroup.WithContext(session.Context())
-
-	gr.Go(func() error {
-		for {
-			select {
-			case msg, ok := <-claim.Messages():
-				if !ok {
-					return nil
-				}
-
-				b.messageQueue <- message{
-					id:    uuid.NewString(),
-					msg:   msg,
-					retry: 0,
-				} // status doesn't matter in messageQueue
-
-			case <-ctx.Done():
-				return ctx.Err()
+	b.mu.RLock()
+	sess := b.sess
+	b.mu.RUnlock()
+
+	if sess == nil {
+		return errors.New("consumer session is not set up")
+	}
+
+	// claim context outlives the session context, so in-flight messages get a chance to finish
+	// after partition is revoked, and it is canceled once draining is over
+	claimCtx, cancel := context.WithCancel(
+		ctxutils.SetLogger(context.WithoutCancel(session.Context()), b.l),
+	)
+	defer cancel()
+
+	// offsets of the claimed partition are committed only up to the highest contiguous processed message
+	offsets := newPartitionOffsets()
+
+	err := b.consumeClaim(session.Context(), claimCtx, sess, claim, offsets)
+
+	// wait for in-flight messages of the partition, the rest are abandoned and will be redelivered
+	if !offsets.WaitDrained(b.drainTimeout) {
+		b.l.Warnf("abandon %d in-flight messages of %s/%d after %s",
+			offsets.Pending(), claim.Topic(), claim.Partition(), b.drainTimeout,
+		)
+	}
+
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
+
+// consumeClaim reads messages of the claim and dispatches them to workers until claim or session is done
+func (b *Broker) consumeClaim(
+	ctx context.Context,
+	claimCtx context.Context,
+	sess *consumerSession,
+	claim sarama.ConsumerGroupClaim,
+	offsets *partitionOffsets,
+) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
 			}
-		}
-	})
-
-	gr.Go(func() error {
-		for {
-			select {
-			case msg, ok := <-b.ackQueue:
-				if !ok {
-					return nil
-				}
-
-				// mark as done if acknowledged or retries exhausted
-				if msg.status == statusSuccess {
-					session.MarkMessage(msg.msg, "")
-					b.l.Infof("successfully process message %s from topic %s", msg.id, msg.msg.Topic)
-				} else {
-					b.l.Errorf("failed to process message %s from topic %s", msg.id, msg.msg.Topic)
-				}
-
-			case <-ctx.Done():
-				return ctx.Err()
+
+			task := message{
+				ctx:     claimCtx,
+				id:      uuid.NewString(),
+				msg:     msg,
+				retry:   retryAttempt(msg),
+				offsets: offsets,
+			} // status doesn't matter in messageQueue
+
+			// wait for delayed messages from retry topics, it blocks only current partition
+			if err := waitRetry(ctx, task); err != nil {
+				return err
 			}
+
+			offsets.Track(msg.Offset)
+
+			if err := b.dispatch(ctx, sess, task); err != nil {
+				offsets.Done(msg.Offset) // message was not dispatched, so nothing to wait for
+				return err
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
 		}
-	})
+	}
+}
 
-	if err := gr.Wait(); err != nil && !errors.Is(err, context.Canceled) {
-		return err
+// dispatch puts the message into the queue of its handler, with ordered keys messages with the same key
+// always go to the same worker, so they are processed in the order they were received
+func (b *Broker) dispatch(ctx context.Context, sess *consumerSession, msg message) error {
+	// check topic to choose the right handler (messages from retry and dead-letter topics keep the original one)
+	pool, ok := sess.pools[sourceTopic(msg.msg)]
+	if !ok {
+		b.l.Warnf("unknown topic %s for %s -> skip it", sourceTopic(msg.msg), msg.id)
+
+		msg.status = statusSuccess
+		sess.ackQueue <- msg
+
+		return nil
 	}
 
-	return nil
+	queue := pool.queue
+
+	if b.orderedKeys {
+		var idx uint32
+
+		if len(msg.msg.Key) > 0 {
+			h := fnv.New32a()
+			h.Write(msg.msg.Key)
+			idx = h.Sum32() % uint32(len(pool.workerQueues))
+		} else {
+			idx = uint32(msg.msg.Offset % int64(len(pool.workerQueues)))
+		}
+
+		queue = pool.workerQueues[idx]
+	}
+
+	select {
+	case queue <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ack logs the result of message processing and marks the offset once all previous messages of the partition are done
+func (b *Broker) ack(session sarama.ConsumerGroupSession, msg message) {
+	switch msg.status {
+	case statusAbandoned:
+		// partition was revoked, new owner will process the message again
+		b.l.Warnf("abandon message %s from topic %s", msg.id, msg.msg.Topic)
+		return
+	case statusRetried:
+		b.l.Warnf("failed to process message %s from topic %s -> retry later", msg.id, msg.msg.Topic)
+	case statusSuccess:
+		b.l.Infof("successfully process message %s from topic %s", msg.id, msg.msg.Topic)
+	case statusDeadLettered:
+		b.l.Errorf("failed to process message %s from topic %s -> dead-lettered", msg.id, msg.msg.Topic)
+	default:
+		// message can't be saved anywhere, so skip it to not block the partition forever
+		b.l.Errorf("failed to process message %s from topic %s", msg.id, msg.msg.Topic)
+	}
+
+	if commit, ok := msg.offsets.Done(msg.msg.Offset); ok {
+		session.MarkOffset(msg.msg.Topic, msg.msg.Partition, commit, "")
+	}
 }
@@ -0,0 +1,145 @@
+package broker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// headers which describe the origin and the processing history of the message
+const (
+	headerOriginalTopic     = "x-original-topic"
+	headerOriginalPartition = "x-original-partition"
+	headerOriginalOffset    = "x-original-offset"
+	headerOriginalTimestamp = "x-original-timestamp"
+	headerAttempts          = "x-attempts"
+	headerLastError         = "x-last-error"
+	headerFailedAt          = "x-failed-at"
+	headerRetryAttempt      = "x-retry-attempt"
+	headerRetryAt           = "x-retry-at"
+)
+
+// getHeader returns the value of the header with the given key
+func getHeader(msg *sarama.ConsumerMessage, key string) (string, bool) {
+	for _, h := range msg.Headers {
+		if h != nil && string(h.Key) == key {
+			return string(h.Value), true
+		}
+	}
+
+	return "", false
+}
+
+// sourceTopic returns the topic the message was originally produced to
+func sourceTopic(msg *sarama.ConsumerMessage) string {
+	if topic, ok := getHeader(msg, headerOriginalTopic); ok && topic != "" {
+		return topic
+	}
+
+	return msg.Topic
+}
+
+// originHeaders returns headers which point to the first place the message was consumed from,
+// so the origin is kept even if the message already went through the dead-letter topic
+func originHeaders(msg *sarama.ConsumerMessage) []sarama.RecordHeader {
+	if topic, ok := getHeader(msg, headerOriginalTopic); ok && topic != "" {
+		headers := []sarama.RecordHeader{}
+
+		for _, key := range []string{
+			headerOriginalTopic,
+			headerOriginalPartition,
+			headerOriginalOffset,
+			headerOriginalTimestamp,
+		} {
+			if val, ok := getHeader(msg, key); ok {
+				headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(val)})
+			}
+		}
+
+		return headers
+	}
+
+	return []sarama.RecordHeader{
+		{Key: []byte(headerOriginalTopic), Value: []byte(msg.Topic)},
+		{Key: []byte(headerOriginalPartition), Value: []byte(strconv.FormatInt(int64(msg.Partition), 10))},
+		{Key: []byte(headerOriginalOffset), Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		{Key: []byte(headerOriginalTimestamp), Value: []byte(msg.Timestamp.UTC().Format(time.RFC3339Nano))},
+	}
+}
+
+// retryAttempt returns how many times the message has already been retried
+func retryAttempt(msg *sarama.ConsumerMessage) int {
+	val, ok := getHeader(msg, headerRetryAttempt)
+	if !ok {
+		return 0
+	}
+
+	attempt, err := strconv.Atoi(val)
+	if err != nil || attempt < 0 {
+		return 0
+	}
+
+	return attempt
+}
+
+// retryAt returns the time before which the message must not be processed
+func retryAt(msg *sarama.ConsumerMessage) (time.Time, bool) {
+	val, ok := getHeader(msg, headerRetryAt)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// newRetryMessage creates a message for the retry topic based on the failed one
+func newRetryMessage(topic string, msg message, delay time.Duration, lastErr error) *sarama.ProducerMessage {
+	headers := originHeaders(msg.msg)
+
+	errText := ""
+	if lastErr != nil {
+		errText = lastErr.Error()
+	}
+
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(headerRetryAttempt), Value: []byte(strconv.Itoa(msg.retry + 1))},
+		sarama.RecordHeader{Key: []byte(headerRetryAt), Value: []byte(time.Now().Add(delay).UTC().Format(time.RFC3339Nano))},
+		sarama.RecordHeader{Key: []byte(headerLastError), Value: []byte(errText)},
+	)
+
+	return &sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.ByteEncoder(msg.msg.Key),
+		Value:   sarama.ByteEncoder(msg.msg.Value),
+		Headers: headers,
+	}
+}
+
+// newDeadLetterMessage creates a message for the dead-letter topic based on the failed one
+func newDeadLetterMessage(topic string, msg message, lastErr error) *sarama.ProducerMessage {
+	headers := originHeaders(msg.msg)
+
+	errText := ""
+	if lastErr != nil {
+		errText = lastErr.Error()
+	}
+
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(headerAttempts), Value: []byte(strconv.Itoa(msg.retry + 1))},
+		sarama.RecordHeader{Key: []byte(headerLastError), Value: []byte(errText)},
+		sarama.RecordHeader{Key: []byte(headerFailedAt), Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	)
+
+	return &sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.ByteEncoder(msg.msg.Key),
+		Value:   sarama.ByteEncoder(msg.msg.Value),
+		Headers: headers,
+	}
+}
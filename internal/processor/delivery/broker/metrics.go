@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName is the instrumentation name of broker metrics
+const meterName = "github.com/keenywheels/backend/internal/processor/delivery/broker"
+
+// registerMetrics registers metrics of worker pools
+func (b *Broker) registerMetrics() error {
+	op := "Broker.registerMetrics"
+
+	meter := b.meterProvider.Meter(meterName)
+
+	queueDepth, err := meter.Int64ObservableGauge(
+		"processor.broker.queue.depth",
+		metric.WithDescription("Number of messages waiting in the queue of the topic worker pool"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to create queue depth gauge: %w", op, err)
+	}
+
+	queueCapacity, err := meter.Int64ObservableGauge(
+		"processor.broker.queue.capacity",
+		metric.WithDescription("Queue depth limit of the topic worker pool"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to create queue capacity gauge: %w", op, err)
+	}
+
+	workers, err := meter.Int64ObservableGauge(
+		"processor.broker.workers",
+		metric.WithDescription("Number of workers of the topic worker pool"),
+		metric.WithUnit("{worker}"),
+	)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to create workers gauge: %w", op, err)
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+
+		for topic, h := range b.handlers {
+			attrs := metric.WithAttributes(attribute.String("topic", topic))
+
+			depth := 0
+			if b.sess != nil {
+				if pool, ok := b.sess.pools[topic]; ok {
+					depth = pool.depth()
+				}
+			}
+
+			o.ObserveInt64(queueDepth, int64(depth), attrs)
+			o.ObserveInt64(queueCapacity, int64(h.pool.QueueSize), attrs)
+			o.ObserveInt64(workers, int64(h.pool.Workers), attrs)
+		}
+
+		return nil
+	}, queueDepth, queueCapacity, workers)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to register metrics callback: %w", op, err)
+	}
+
+	return nil
+}
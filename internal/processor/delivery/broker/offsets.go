@@ -0,0 +1,92 @@
+package broker
+
+import (
+	"sync"
+	"time"
+)
+
+// partitionOffsets tracks in-flight messages of a single partition, so the committed offset
+// only moves forward when all messages before it are completed
+type partitionOffsets struct {
+	mu sync.Mutex
+
+	// inflight holds offsets in the order they were dispatched to workers
+	inflight []int64
+	done     map[int64]struct{}
+
+	// changed is notified every time a message is completed
+	changed chan struct{}
+}
+
+// newPartitionOffsets creates a new partitionOffsets instance
+func newPartitionOffsets() *partitionOffsets {
+	return &partitionOffsets{
+		done:    make(map[int64]struct{}),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Track registers the offset as dispatched, offsets must be tracked in ascending order
+func (p *partitionOffsets) Track(offset int64) {
+	p.mu.Lock()
+	p.inflight = append(p.inflight, offset)
+	p.mu.Unlock()
+}
+
+// Done marks the offset as completed and returns the next offset to commit
+// if the highest contiguous completed offset has moved
+func (p *partitionOffsets) Done(offset int64) (int64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done[offset] = struct{}{}
+
+	var (
+		commit   int64
+		advanced bool
+	)
+
+	for len(p.inflight) > 0 {
+		head := p.inflight[0]
+		if _, ok := p.done[head]; !ok {
+			break
+		}
+
+		delete(p.done, head)
+		p.inflight = p.inflight[1:]
+
+		commit = head + 1 // kafka expects the offset of the next message to consume
+		advanced = true
+	}
+
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+
+	return commit, advanced
+}
+
+// Pending returns the number of dispatched but not completed messages
+func (p *partitionOffsets) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.inflight)
+}
+
+// WaitDrained waits until all dispatched messages are completed or timeout expires
+func (p *partitionOffsets) WaitDrained(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for p.Pending() > 0 {
+		select {
+		case <-p.changed:
+		case <-timer.C:
+			return p.Pending() == 0
+		}
+	}
+
+	return true
+}
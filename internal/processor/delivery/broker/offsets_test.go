@@ -0,0 +1,81 @@
+package broker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartitionOffsetsCommitContiguous(t *testing.T) {
+	p := newPartitionOffsets()
+	for offset := int64(10); offset < 15; offset++ {
+		p.Track(offset)
+	}
+
+	steps := []struct {
+		done     int64
+		commit   int64
+		advanced bool
+	}{
+		{done: 12, advanced: false}, // 10 and 11 are still in flight
+		{done: 11, advanced: false}, // 10 is still in flight
+		{done: 10, commit: 13, advanced: true},
+		{done: 14, advanced: false}, // 13 is still in flight
+		{done: 13, commit: 15, advanced: true},
+	}
+
+	for _, step := range steps {
+		commit, advanced := p.Done(step.done)
+		if advanced != step.advanced || (advanced && commit != step.commit) {
+			t.Fatalf("done %d: expected commit=%d advanced=%v, got commit=%d advanced=%v",
+				step.done, step.commit, step.advanced, commit, advanced)
+		}
+	}
+
+	if pending := p.Pending(); pending != 0 {
+		t.Fatalf("expected no pending offsets, got %d", pending)
+	}
+}
+
+func TestPartitionOffsetsRedeliveryAfterCrash(t *testing.T) {
+	p := newPartitionOffsets()
+	for offset := int64(0); offset < 6; offset++ {
+		p.Track(offset)
+	}
+
+	// workers completed messages out of order, 2 is still processed when the consumer crashes
+	var committed int64
+	for _, offset := range []int64{0, 1, 3, 4, 5} {
+		if commit, ok := p.Done(offset); ok {
+			committed = commit
+		}
+	}
+
+	if committed != 2 {
+		t.Fatalf("expected committed offset 2, got %d", committed)
+	}
+
+	// new consumer of the partition resumes from the committed offset, so completed 3, 4 and 5
+	// are delivered again together with 2 and stay pending here
+	if pending := p.Pending(); pending != 4 {
+		t.Fatalf("expected offsets 2..5 to be redelivered, got %d pending", pending)
+	}
+}
+
+func TestPartitionOffsetsWaitDrained(t *testing.T) {
+	p := newPartitionOffsets()
+	p.Track(0)
+	p.Track(1)
+
+	if p.WaitDrained(10 * time.Millisecond) {
+		t.Fatal("expected timeout while messages are in flight")
+	}
+
+	go func() {
+		p.Done(1)
+		p.Done(0)
+	}()
+
+	if !p.WaitDrained(time.Second) {
+		t.Fatalf("expected drained partition, got %d pending", p.Pending())
+	}
+}
@@ -1,6 +1,9 @@
 package broker
 
-import "github.com/keenywheels/backend/pkg/logger"
+import (
+	"github.com/keenywheels/backend/pkg/logger"
+	"go.opentelemetry.io/otel/metric"
+)
 
 type Option func(*Broker)
 
@@ -10,3 +13,10 @@ func WithLogger(l logger.Logger) Option {
 		b.l = l
 	}
 }
+
+// WithMeterProvider sets the meter provider used for broker metrics
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(b *Broker) {
+		b.meterProvider = mp
+	}
+}
@@ -0,0 +1,131 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// HandlerFunc handles a single message from the topic
+type HandlerFunc func(ctx context.Context, message string) error
+
+// HandlerConfig holds settings of the topic handler, zero values are replaced with broker defaults,
+// worker pool of the handler is configured in broker Config by the topic
+type HandlerConfig struct {
+	// MaxRetry is how many times the message is retried through retry topics before dead-lettering
+	MaxRetry int
+
+	// RetryDelays are delays of retry topics, the last one is used for all further retries
+	RetryDelays []time.Duration
+
+	// Timeout limits the time of a single message processing, no limit if zero
+	Timeout time.Duration
+
+	// Concurrency limits the number of messages processed at the same time, including workers
+	// of the previous session which are still draining after rebalance, no limit if zero
+	Concurrency int
+
+	// DeadLetterTopic is where messages go after all retries failed, messages are dropped if empty
+	DeadLetterTopic string
+}
+
+// topicHandler represents registered handler with its settings
+type topicHandler struct {
+	topic  string
+	handle HandlerFunc
+	cfg    HandlerConfig
+	pool   PoolConfig
+
+	// sem limits concurrent executions of the handler, nil if there is no limit
+	sem chan struct{}
+}
+
+// Register registers the handler for messages from the topic (and its retry topics)
+func (b *Broker) Register(topic string, handler HandlerFunc, cfg HandlerConfig) error {
+	if topic == "" {
+		return errors.New("empty topic")
+	}
+
+	if handler == nil {
+		return fmt.Errorf("nil handler for topic %s", topic)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.handlers[topic]; ok {
+		return fmt.Errorf("handler for topic %s is already registered", topic)
+	}
+
+	// set default values
+	pool := b.pools[topic]
+	if pool.Workers <= 0 {
+		pool.Workers = b.workerCount
+	}
+
+	if pool.QueueSize <= 0 {
+		pool.QueueSize = b.queueSize
+	}
+
+	if pool.QueueSize <= 0 {
+		pool.QueueSize = pool.Workers * 2
+	}
+
+	if cfg.MaxRetry <= 0 {
+		cfg.MaxRetry = b.maxRetry
+	}
+
+	if len(cfg.RetryDelays) == 0 {
+		cfg.RetryDelays = b.retryDelays
+	}
+
+	h := &topicHandler{
+		topic:  topic,
+		handle: handler,
+		cfg:    cfg,
+		pool:   pool,
+	}
+
+	if cfg.Concurrency > 0 {
+		h.sem = make(chan struct{}, cfg.Concurrency)
+	}
+
+	b.handlers[topic] = h
+
+	return nil
+}
+
+// Topics returns all registered source topics
+func (b *Broker) Topics() []string {
+	topics := make([]string, 0, len(b.handlers))
+	for topic := range b.handlers {
+		topics = append(topics, topic)
+	}
+
+	slices.Sort(topics)
+
+	return topics
+}
+
+// run runs the handler respecting its concurrency limit and timeout
+func (h *topicHandler) run(ctx context.Context, message string) error {
+	if h.sem != nil {
+		select {
+		case h.sem <- struct{}{}:
+			defer func() { <-h.sem }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if h.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, h.cfg.Timeout)
+		defer cancel()
+	}
+
+	return h.handle(ctx, message)
+}
@@ -0,0 +1,86 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// retryTopic returns the name of the retry topic for the source topic and delay, e.g. scraper_data.retry-10s
+func retryTopic(source string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry-%s", source, formatDelay(delay))
+}
+
+// formatDelay formats delay in the shortest human-readable form: 10s, 1m, 10m, 2h
+func formatDelay(delay time.Duration) string {
+	switch {
+	case delay >= time.Hour && delay%time.Hour == 0:
+		return fmt.Sprintf("%dh", delay/time.Hour)
+	case delay >= time.Minute && delay%time.Minute == 0:
+		return fmt.Sprintf("%dm", delay/time.Minute)
+	case delay >= time.Second && delay%time.Second == 0:
+		return fmt.Sprintf("%ds", delay/time.Second)
+	default:
+		return fmt.Sprintf("%dms", delay/time.Millisecond)
+	}
+}
+
+// retryDelay returns delay for the given retry attempt (starting from 0),
+// the last delay is used for all attempts exceeding the number of delays
+func (h *topicHandler) retryDelay(attempt int) time.Duration {
+	return h.cfg.RetryDelays[min(attempt, len(h.cfg.RetryDelays)-1)]
+}
+
+// retryTopics returns retry topics used by the handler
+func (h *topicHandler) retryTopics() []string {
+	var (
+		topics []string
+		seen   = make(map[time.Duration]struct{}, len(h.cfg.RetryDelays))
+	)
+
+	for _, delay := range h.cfg.RetryDelays[:min(h.cfg.MaxRetry, len(h.cfg.RetryDelays))] {
+		if _, ok := seen[delay]; ok {
+			continue
+		}
+
+		seen[delay] = struct{}{}
+		topics = append(topics, retryTopic(h.topic, delay))
+	}
+
+	return topics
+}
+
+// RetryTopics returns all retry topics which should be consumed along with the source topics
+func (b *Broker) RetryTopics() []string {
+	var topics []string
+
+	for _, source := range b.Topics() {
+		topics = append(topics, b.handlers[source].retryTopics()...)
+	}
+
+	return topics
+}
+
+// waitRetry blocks until the message from retry topic is due, messages in the retry topic
+// have the same delay, so waiting for the head of partition does not delay the ones behind it
+func waitRetry(ctx context.Context, msg message) error {
+	at, ok := retryAt(msg.msg)
+	if !ok {
+		return nil
+	}
+
+	wait := time.Until(at)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
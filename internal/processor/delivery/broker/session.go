@@ -0,0 +1,122 @@
+package broker
+
+import (
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// workerPool holds queues and workers of a single handler within the session
+type workerPool struct {
+	handler *topicHandler
+
+	queue        chan message
+	workerQueues []chan message
+
+	wg sync.WaitGroup
+}
+
+// consumerSession holds worker pools of a single consumer group session,
+// every session after rebalance gets its own set, so closed channels are never reused
+type consumerSession struct {
+	session sarama.ConsumerGroupSession
+
+	// pools maps source topic to the worker pool of its handler
+	pools    map[string]*workerPool
+	ackQueue chan message
+
+	ackWg sync.WaitGroup
+}
+
+// newConsumerSession creates queues for the new consumer group session
+func (b *Broker) newConsumerSession(session sarama.ConsumerGroupSession) *consumerSession {
+	// handlers may be registered while the consumer is running
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	s := &consumerSession{
+		session: session,
+		pools:   make(map[string]*workerPool, len(b.handlers)),
+	}
+
+	ackSize := 0
+
+	for topic, h := range b.handlers {
+		pool := &workerPool{
+			handler: h,
+			queue:   make(chan message, h.pool.QueueSize),
+		}
+
+		if b.orderedKeys {
+			// queue depth is split between workers, so every worker has room for at least one message
+			size := max(h.pool.QueueSize/h.pool.Workers, 1)
+
+			pool.workerQueues = make([]chan message, h.pool.Workers)
+			for i := range pool.workerQueues {
+				pool.workerQueues[i] = make(chan message, size)
+			}
+		}
+
+		s.pools[topic] = pool
+		ackSize += h.pool.Workers * 2
+	}
+
+	s.ackQueue = make(chan message, ackSize)
+
+	return s
+}
+
+// start starts workers of every pool and ack loop of the session
+func (b *Broker) start(s *consumerSession) {
+	for _, pool := range s.pools {
+		for i := 0; i < pool.handler.pool.Workers; i++ {
+			queue := pool.queue
+			if b.orderedKeys {
+				queue = pool.workerQueues[i]
+			}
+
+			pool.wg.Add(1)
+			go func() {
+				defer pool.wg.Done()
+				b.worker(s, pool.handler, i, queue)
+			}()
+		}
+	}
+
+	s.ackWg.Add(1)
+	go func() {
+		defer s.ackWg.Done()
+
+		for msg := range s.ackQueue {
+			b.ack(s.session, msg)
+		}
+	}()
+}
+
+// depth returns the number of messages waiting in the queues of the pool
+func (p *workerPool) depth() int {
+	depth := len(p.queue)
+	for _, queue := range p.workerQueues {
+		depth += len(queue)
+	}
+
+	return depth
+}
+
+// stop closes queues of the session and waits for workers and ack loop to finish,
+// should be called only after all claims of the session are done
+func (s *consumerSession) stop() {
+	for _, pool := range s.pools {
+		close(pool.queue)
+		for _, queue := range pool.workerQueues {
+			close(queue)
+		}
+	}
+
+	for _, pool := range s.pools {
+		pool.wg.Wait()
+	}
+
+	close(s.ackQueue)
+	s.ackWg.Wait()
+}
@@ -7,79 +7,93 @@ import (
 
 const maxLogMsgLength = 25
 
-// worker processes messages from the message queue
-func (b *Broker) worker(ctx context.Context, i int) {
-	defer b.wg.Done()
-
+// worker processes messages from the queue until the queue of the session is closed
+func (b *Broker) worker(sess *consumerSession, h *topicHandler, i int, queue <-chan message) {
 	var (
-		prefix = fmt.Sprintf("WORKER %d", i)
+		prefix = fmt.Sprintf("WORKER %s/%d", h.topic, i)
 	)
 
-	for msg := range b.messageQueue {
-		if ctx.Err() != nil {
-			return
+	for msg := range queue {
+		// partition was revoked and draining is over -> skip message without committing it
+		if msg.ctx.Err() != nil {
+			msg.status = statusAbandoned
+			sess.ackQueue <- msg
+
+			continue
 		}
 
 		b.l.Infof("[%s] got new message from topic %s with id=%s", prefix, msg.msg.Topic, msg.id)
-		b.processTasks(ctx, msg)
+
+		msg.status = b.processTasks(msg.ctx, h, msg)
+		sess.ackQueue <- msg
+	}
+}
+
+// processTasks processes the message and returns its status
+func (b *Broker) processTasks(ctx context.Context, h *topicHandler, msg message) string {
+	op := "Broker.processTasks"
+
+	err := h.run(ctx, string(msg.msg.Value))
+
+	// no error -> mark message as success
+	if err == nil {
+		return statusSuccess
+	}
+
+	// processing was interrupted because partition was revoked -> new owner will process it
+	if ctx.Err() != nil {
+		b.l.Warnf("[%s] processing of message %s was interrupted: %v", op, msg.id, err)
+		return statusAbandoned
+	}
+
+	b.l.Errorf("[%s] failed to process message %s from topic %s: %v", op, msg.id, msg.msg.Topic, err)
+
+	// put task into retry topic, so it will be processed later even after restart
+	if msg.retry < h.cfg.MaxRetry {
+		return b.putRetryTask(h, msg, err)
 	}
+
+	// all retries exhausted -> move message to dead-letter topic and ack it
+	b.l.Errorf("[%s] failed to process message %s after %d retries -> ack it", op, msg.id, h.cfg.MaxRetry)
+
+	return b.putDeadLetterTask(h, msg, err)
 }
 
-// processTasks processes messages from the message queue
-func (b *Broker) processTasks(ctx context.Context, msg message) {
+// putDeadLetterTask sends a message to the dead-letter topic and returns its final status
+func (b *Broker) putDeadLetterTask(h *topicHandler, msg message, lastErr error) string {
 	var (
-		op  = "Broker.processTasks"
-		err error
+		op    = "Broker.putDeadLetterTask"
+		topic = h.cfg.DeadLetterTopic
 	)
 
-	// check topic to choose the right handler
-	switch msg.msg.Topic {
-	case b.topics.ScraperData:
-		err = b.service.TokenizeMessage(ctx, string(msg.msg.Value))
-	case b.topics.Notifications:
-		err = b.service.NotifyUser(ctx, string(msg.msg.Value))
-	default:
-		b.l.Warnf("[%s] unknown topic %s for %s", op, msg.msg.Topic, msg.id)
+	if topic == "" {
+		return statusFailed
 	}
 
-	// parse error
-	if err != nil {
-		b.l.Errorf("[%s] failed to process message %s from topic %s: %v", op, msg.id, msg.msg.Topic, err)
-
-		// try to retry task
-		if msg.retry < b.maxRetry {
-			go func() {
-				defer func() {
-					if rec := recover(); rec != nil {
-						b.l.Errorf("[%s] panic while processing message %s: %v", op, msg.id, rec)
-					}
-				}()
-
-				b.putRetryTask(ctx, msg)
-			}()
-		} else {
-			// all retries exhausted -> mark message as failed and ack it
-			b.l.Errorf("[%s] failed to process message %s after %d retries -> ack it", op, msg.id, b.maxRetry)
-			msg.status = statusFailed
-		}
-	} else {
-		// no error -> mark message as success
-		msg.status = statusSuccess
+	if err := b.producer.Produce(newDeadLetterMessage(topic, msg, lastErr)); err != nil {
+		b.l.Errorf("[%s] failed to send message %s to dead-letter topic %s: %v", op, msg.id, topic, err)
+		return statusFailed
 	}
 
-	b.ackQueue <- msg
+	b.l.Warnf("[%s] message %s moved to dead-letter topic %s", op, msg.id, topic)
+
+	return statusDeadLettered
 }
 
-// putRetryTask puts a message back to the queue for retrying
-func (b *Broker) putRetryTask(ctx context.Context, msg message) {
-	timeoutCtx, cancel := context.WithTimeout(ctx, b.retryDelay)
-	defer cancel()
-
-	select {
-	case <-timeoutCtx.Done():
-		msg.retry++
-		b.messageQueue <- msg
-	case <-ctx.Done():
-		return
+// putRetryTask sends a message to the retry topic with delay based on the retry attempt
+func (b *Broker) putRetryTask(h *topicHandler, msg message, lastErr error) string {
+	var (
+		op    = "Broker.putRetryTask"
+		delay = h.retryDelay(msg.retry)
+		topic = retryTopic(h.topic, delay)
+	)
+
+	if err := b.producer.Produce(newRetryMessage(topic, msg, delay, lastErr)); err != nil {
+		b.l.Errorf("[%s] failed to send message %s to retry topic %s: %v -> dead-letter it", op, msg.id, topic, err)
+		return b.putDeadLetterTask(h, msg, lastErr)
 	}
+
+	b.l.Infof("[%s] message %s put to retry topic %s, attempt=%d", op, msg.id, topic, msg.retry+1)
+
+	return statusRetried
 }
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
 const (
 	ScrapeDataFormat = "02-01-2006"
 )
@@ -11,3 +17,29 @@ type ScraperEvent struct {
 	Msg      string `json:"msg"`
 	Date     string `json:"date"`
 }
+
+// Fingerprint returns deterministic fingerprint of the event based on site, date and message hash
+func (e *ScraperEvent) Fingerprint() string {
+	msgHash := sha256.Sum256([]byte(e.Msg))
+
+	h := sha256.New()
+	h.Write([]byte(e.SiteName))
+	h.Write([]byte{0})
+	h.Write([]byte(e.Date))
+	h.Write([]byte{0})
+	h.Write(msgHash[:])
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IngestedEvent represents already processed scraper event in postgres database
+type IngestedEvent struct {
+	Fingerprint string
+	SiteName    string
+	Date        time.Time
+
+	// SimHash is fingerprint of the message text, nil if message is too short,
+	// Duplicate is set if the message is near-duplicate of already ingested one
+	SimHash   *uint64
+	Duplicate bool
+}
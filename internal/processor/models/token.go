@@ -11,4 +11,40 @@ type TokenData struct {
 	SiteName  string
 	Category  string
 	Date      time.Time
+
+	// SentimentScore is continuous sentiment from -1 to 1, SentimentConfidence is nil if provider
+	// does not return it, mentions are numbers of positive, neutral and negative mentions of the token
+	SentimentScore      float64
+	SentimentConfidence *float64
+	PositiveMentions    int64
+	NeutralMentions     int64
+	NegativeMentions    int64
+
+	// SurfaceForms counts original forms of the token in the message, e.g. "продукция" for "продукц"
+	SurfaceForms map[string]int64
+
+	// CoOccurrences counts tokens met within the context window of the token in the message
+	CoOccurrences map[string]int64
+
+	// SentimentPending is set if sentiment was not analyzed yet, Context is saved to analyze it later
+	SentimentPending bool
+	Context          string
+}
+
+// SentimentBacklogItem represents token waiting for sentiment analysis
+type SentimentBacklogItem struct {
+	TokenID  int64
+	Context  string
+	Attempts int
+}
+
+// TokenSentiment represents analyzed sentiment of the token
+type TokenSentiment struct {
+	TokenID             int64
+	Sentiment           int16
+	SentimentScore      float64
+	SentimentConfidence *float64
+	PositiveMentions    int64
+	NeutralMentions     int64
+	NegativeMentions    int64
 }
@@ -3,6 +3,7 @@ package processor
 import (
 	"flag"
 	"os"
+	"strconv"
 )
 
 // default opts values
@@ -13,11 +14,13 @@ const (
 // envs
 const (
 	envConfigPath = "CONFIG_PATH"
+	envReplayDLQ  = "REPLAY_DLQ"
 )
 
 // Options represents application's options
 type Options struct {
 	ConfigPath string
+	ReplayDLQ  bool
 }
 
 // NewDefaultOpts creates default options
@@ -32,10 +35,15 @@ func (opts *Options) LoadEnv() {
 	if val, ok := os.LookupEnv(envConfigPath); ok {
 		opts.ConfigPath = val
 	}
+
+	if val, ok := os.LookupEnv(envReplayDLQ); ok {
+		opts.ReplayDLQ, _ = strconv.ParseBool(val)
+	}
 }
 
 // LoadFlags updates options with values from cmd flags
 func (opts *Options) LoadFlags() {
 	flag.StringVar(&opts.ConfigPath, "config", defaultConfigPath, "path to config file")
+	flag.BoolVar(&opts.ReplayDLQ, "replay-dlq", opts.ReplayDLQ, "replay messages from dead-letter topics instead of regular consuming")
 	flag.Parse()
 }
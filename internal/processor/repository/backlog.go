@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/keenywheels/backend/internal/processor/models"
+)
+
+// insertBacklog inserts tokens waiting for sentiment analysis into the backlog
+func (r *Repository) insertBacklog(ctx context.Context, tx pgx.Tx, items []models.SentimentBacklogItem) error {
+	op := "Repository.insertBacklog"
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	builder := r.db.Builder.Insert(r.backlogTbl.Name).
+		Columns(
+			r.backlogTbl.Fields.TokenID,
+			r.backlogTbl.Fields.Context,
+		)
+
+	for _, item := range items {
+		builder = builder.Values(item.TokenID, item.Context)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("[%s] failed to build insert query: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("[%s] failed to insert backlog: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetSentimentBacklog returns the oldest tokens waiting for sentiment analysis
+func (r *Repository) GetSentimentBacklog(ctx context.Context, limit uint64) ([]models.SentimentBacklogItem, error) {
+	op := "Repository.GetSentimentBacklog"
+
+	query, args, err := r.db.Builder.
+		Select(
+			r.backlogTbl.Fields.TokenID,
+			r.backlogTbl.Fields.Context,
+			r.backlogTbl.Fields.Attempts,
+		).
+		From(r.backlogTbl.Name).
+		OrderBy(r.backlogTbl.Fields.Attempts, r.backlogTbl.Fields.CreatedAt).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[%s] failed to build select query: %w", op, err)
+	}
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] failed to get backlog: %w", op, err)
+	}
+	defer rows.Close()
+
+	var items []models.SentimentBacklogItem
+
+	for rows.Next() {
+		var item models.SentimentBacklogItem
+		if err := rows.Scan(&item.TokenID, &item.Context, &item.Attempts); err != nil {
+			return nil, fmt.Errorf("[%s] failed to scan row: %w", op, err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[%s] failed to read rows: %w", op, err)
+	}
+
+	return items, nil
+}
+
+// ResolveSentiments saves analyzed sentiments of tokens and removes them from the backlog
+func (r *Repository) ResolveSentiments(ctx context.Context, sentiments []models.TokenSentiment) error {
+	op := "Repository.ResolveSentiments"
+
+	if len(sentiments) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		batch    = &pgx.Batch{}
+		tokenIDs = make([]int64, 0, len(sentiments))
+	)
+
+	for _, s := range sentiments {
+		query, args, err := r.db.Builder.Update(r.tbl.Name).
+			Set(r.tbl.Fields.Sentiment, s.Sentiment).
+			Set(r.tbl.Fields.SentimentScore, s.SentimentScore).
+			Set(r.tbl.Fields.SentimentConfidence, s.SentimentConfidence).
+			Set(r.tbl.Fields.PositiveMentions, s.PositiveMentions).
+			Set(r.tbl.Fields.NeutralMentions, s.NeutralMentions).
+			Set(r.tbl.Fields.NegativeMentions, s.NegativeMentions).
+			Set(r.tbl.Fields.SentimentPending, false).
+			Where(sq.Eq{r.tbl.Fields.TokenID: s.TokenID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("[%s] failed to build update query: %w", op, err)
+		}
+
+		batch.Queue(query, args...)
+		tokenIDs = append(tokenIDs, s.TokenID)
+	}
+
+	query, args, err := r.db.Builder.Delete(r.backlogTbl.Name).
+		Where(sq.Eq{r.backlogTbl.Fields.TokenID: tokenIDs}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("[%s] failed to build delete query: %w", op, err)
+	}
+
+	batch.Queue(query, args...)
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("[%s] failed to execute batch: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[%s] failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// IncBacklogAttempts increments failed attempts of tokens in the backlog,
+// so they are moved behind the other ones
+func (r *Repository) IncBacklogAttempts(ctx context.Context, tokenIDs []int64) error {
+	op := "Repository.IncBacklogAttempts"
+
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+
+	query, args, err := r.db.Builder.Update(r.backlogTbl.Name).
+		Set(r.backlogTbl.Fields.Attempts, sq.Expr(r.backlogTbl.Fields.Attempts+" + 1")).
+		Where(sq.Eq{r.backlogTbl.Fields.TokenID: tokenIDs}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("[%s] failed to build update query: %w", op, err)
+	}
+
+	if _, err := r.db.Pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("[%s] failed to update backlog: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteBacklog removes tokens from the backlog without resolving their sentiment,
+// so they stay pending and are not retried anymore
+func (r *Repository) DeleteBacklog(ctx context.Context, tokenIDs []int64) error {
+	op := "Repository.DeleteBacklog"
+
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+
+	query, args, err := r.db.Builder.Delete(r.backlogTbl.Name).
+		Where(sq.Eq{r.backlogTbl.Fields.TokenID: tokenIDs}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("[%s] failed to build delete query: %w", op, err)
+	}
+
+	if _, err := r.db.Pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("[%s] failed to delete backlog: %w", op, err)
+	}
+
+	return nil
+}
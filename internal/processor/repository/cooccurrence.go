@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/keenywheels/backend/internal/processor/models"
+)
+
+// coOccurrence represents number of times the tokens were met within the context window
+type coOccurrence struct {
+	tokenName    string
+	relatedToken string
+	category     string
+	mentions     int64
+}
+
+// upsertCoOccurrences adds co-occurrences of the tokens to the counters of the day and category
+func (r *Repository) upsertCoOccurrences(ctx context.Context, tx pgx.Tx, date time.Time, tokens []models.TokenData) error {
+	op := "Repository.upsertCoOccurrences"
+
+	var pairs []coOccurrence
+
+	for _, token := range tokens {
+		for related, mentions := range token.CoOccurrences {
+			pairs = append(pairs, coOccurrence{
+				tokenName:    token.TokenName,
+				relatedToken: related,
+				category:     token.Category,
+				mentions:     mentions,
+			})
+		}
+	}
+
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	// rows are locked in the same order by all transactions, so concurrent upserts can't deadlock
+	slices.SortFunc(pairs, func(a, b coOccurrence) int {
+		return cmp.Or(
+			cmp.Compare(a.tokenName, b.tokenName),
+			cmp.Compare(a.category, b.category),
+			cmp.Compare(a.relatedToken, b.relatedToken),
+		)
+	})
+
+	for chunk := range slices.Chunk(pairs, maxBatchSize) {
+		builder := r.db.Builder.Insert(r.coOccurrenceTbl.Name).
+			Columns(
+				r.coOccurrenceTbl.Fields.TokenName,
+				r.coOccurrenceTbl.Fields.Date,
+				r.coOccurrenceTbl.Fields.Category,
+				r.coOccurrenceTbl.Fields.RelatedToken,
+				r.coOccurrenceTbl.Fields.Mentions,
+			).
+			Suffix(fmt.Sprintf(
+				"ON CONFLICT (%[1]s, %[2]s, %[3]s, %[4]s) DO UPDATE SET %[5]s = %[6]s.%[5]s + EXCLUDED.%[5]s",
+				r.coOccurrenceTbl.Fields.TokenName,
+				r.coOccurrenceTbl.Fields.Date,
+				r.coOccurrenceTbl.Fields.Category,
+				r.coOccurrenceTbl.Fields.RelatedToken,
+				r.coOccurrenceTbl.Fields.Mentions,
+				r.coOccurrenceTbl.Name,
+			))
+
+		for _, p := range chunk {
+			builder = builder.Values(p.tokenName, date, p.category, p.relatedToken, p.mentions)
+		}
+
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return fmt.Errorf("[%s] failed to build upsert query: %w", op, err)
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("[%s] failed to upsert co-occurrences: %w", op, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+// common repository layer errors
+var (
+	ErrAlreadyIngested = errors.New("event already ingested")
+)
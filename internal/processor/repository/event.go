@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/simhash"
+)
+
+// IsIngested checks whether the event with the fingerprint was already ingested
+func (r *Repository) IsIngested(ctx context.Context, fingerprint string) (bool, error) {
+	op := "Repository.IsIngested"
+
+	query, args, err := r.db.Builder.Select("1").
+		Prefix("SELECT EXISTS (").
+		From(r.eventsTbl.Name).
+		Where(sq.Eq{r.eventsTbl.Fields.Fingerprint: fingerprint}).
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("[%s] failed to build select query: %w", op, err)
+	}
+
+	var exists bool
+	if err := r.db.Pool.QueryRow(ctx, query, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("[%s] failed to check event: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+// FindNearDuplicateParams represents parameters for looking up near-duplicate of the message
+type FindNearDuplicateParams struct {
+	SimHash     uint64
+	From        time.Time
+	To          time.Time
+	MaxDistance int
+}
+
+// FindNearDuplicate returns fingerprint of the event ingested between From and To which message
+// SimHash differs in at most MaxDistance bits, returns empty string if there is no such event
+func (r *Repository) FindNearDuplicate(ctx context.Context, params *FindNearDuplicateParams) (string, error) {
+	op := "Repository.FindNearDuplicate"
+
+	// fingerprints which differ in less bits than number of bands have at least one equal band
+	bands := sq.Or{}
+	for i, band := range simhash.Split(params.SimHash) {
+		bands = append(bands, sq.Eq{r.eventsTbl.Fields.SimHashBands[i]: int32(band)})
+	}
+
+	query, args, err := r.db.Builder.
+		Select(
+			r.eventsTbl.Fields.Fingerprint,
+			r.eventsTbl.Fields.SimHash,
+		).
+		From(r.eventsTbl.Name).
+		Where(sq.And{
+			bands,
+			sq.GtOrEq{r.eventsTbl.Fields.Date: params.From},
+			sq.LtOrEq{r.eventsTbl.Fields.Date: params.To},
+		}).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("[%s] failed to build select query: %w", op, err)
+	}
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("[%s] failed to select candidates: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			fingerprint string
+			hash        int64
+		)
+
+		if err := rows.Scan(&fingerprint, &hash); err != nil {
+			return "", fmt.Errorf("[%s] failed to scan candidate: %w", op, err)
+		}
+
+		if simhash.Distance(uint64(hash), params.SimHash) <= params.MaxDistance {
+			return fingerprint, nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("[%s] failed to select candidates: %w", op, err)
+	}
+
+	return "", nil
+}
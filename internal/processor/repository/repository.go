@@ -1,6 +1,9 @@
 package repository
 
-import "github.com/keenywheels/backend/pkg/postgres"
+import (
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/simhash"
+	"github.com/keenywheels/backend/pkg/postgres"
+)
 
 // TokenDataFields represents the fields of the token data table
 type TokenDataFields struct {
@@ -11,6 +14,13 @@ type TokenDataFields struct {
 	Category  string
 	SiteName  string
 	Date      string
+
+	SentimentPending    string
+	SentimentScore      string
+	SentimentConfidence string
+	PositiveMentions    string
+	NeutralMentions     string
+	NegativeMentions    string
 }
 
 // TokenDataTable represents the structure of the token data table
@@ -19,10 +29,73 @@ type TokenDataTable struct {
 	Fields TokenDataFields
 }
 
+// IngestedEventsFields represents the fields of the ingested events table
+type IngestedEventsFields struct {
+	Fingerprint  string
+	SiteName     string
+	Date         string
+	SimHash      string
+	SimHashBands [simhash.Bands]string
+	Duplicate    string
+}
+
+// IngestedEventsTable represents the structure of the ingested events table
+type IngestedEventsTable struct {
+	Name   string
+	Fields IngestedEventsFields
+}
+
+// SentimentBacklogFields represents the fields of the sentiment backlog table
+type SentimentBacklogFields struct {
+	TokenID   string
+	Context   string
+	Attempts  string
+	CreatedAt string
+}
+
+// SentimentBacklogTable represents the structure of the sentiment backlog table
+type SentimentBacklogTable struct {
+	Name   string
+	Fields SentimentBacklogFields
+}
+
+// SurfaceFormsFields represents the fields of the token surface forms table
+type SurfaceFormsFields struct {
+	TokenName   string
+	Date        string
+	SurfaceForm string
+	Mentions    string
+}
+
+// SurfaceFormsTable represents the structure of the token surface forms table
+type SurfaceFormsTable struct {
+	Name   string
+	Fields SurfaceFormsFields
+}
+
+// CoOccurrenceFields represents the fields of the token co-occurrence table
+type CoOccurrenceFields struct {
+	TokenName    string
+	RelatedToken string
+	Category     string
+	Date         string
+	Mentions     string
+}
+
+// CoOccurrenceTable represents the structure of the token co-occurrence table
+type CoOccurrenceTable struct {
+	Name   string
+	Fields CoOccurrenceFields
+}
+
 // Repository struct for repository layer
 type Repository struct {
-	tbl TokenDataTable
-	db  *postgres.Postgres
+	tbl             TokenDataTable
+	eventsTbl       IngestedEventsTable
+	backlogTbl      SentimentBacklogTable
+	surfaceFormsTbl SurfaceFormsTable
+	coOccurrenceTbl CoOccurrenceTable
+	db              *postgres.Postgres
 }
 
 // New creates a new Repository instance
@@ -37,11 +110,70 @@ func New(db *postgres.Postgres) *Repository {
 			Category:  "category",
 			SiteName:  "site_name",
 			Date:      "scrape_date",
+
+			SentimentPending:    "sentiment_pending",
+			SentimentScore:      "sentiment_score",
+			SentimentConfidence: "sentiment_confidence",
+			PositiveMentions:    "mentions_positive",
+			NeutralMentions:     "mentions_neutral",
+			NegativeMentions:    "mentions_negative",
+		},
+	}
+
+	eventsTbl := IngestedEventsTable{
+		Name: "ingested_events",
+		Fields: IngestedEventsFields{
+			Fingerprint: "fingerprint",
+			SiteName:    "site_name",
+			Date:        "scrape_date",
+			SimHash:     "simhash",
+			SimHashBands: [simhash.Bands]string{
+				"simhash_band_0",
+				"simhash_band_1",
+				"simhash_band_2",
+				"simhash_band_3",
+			},
+			Duplicate: "duplicate",
+		},
+	}
+
+	backlogTbl := SentimentBacklogTable{
+		Name: "sentiment_backlog",
+		Fields: SentimentBacklogFields{
+			TokenID:   "token_id",
+			Context:   "context",
+			Attempts:  "attempts",
+			CreatedAt: "created_at",
+		},
+	}
+
+	surfaceFormsTbl := SurfaceFormsTable{
+		Name: "token_surface_forms",
+		Fields: SurfaceFormsFields{
+			TokenName:   "token_name",
+			Date:        "scrape_date",
+			SurfaceForm: "surface_form",
+			Mentions:    "mentions",
+		},
+	}
+
+	coOccurrenceTbl := CoOccurrenceTable{
+		Name: "token_cooccurrence",
+		Fields: CoOccurrenceFields{
+			TokenName:    "token_name",
+			RelatedToken: "related_token",
+			Category:     "category",
+			Date:         "scrape_date",
+			Mentions:     "mentions",
 		},
 	}
 
 	return &Repository{
-		tbl: tbl,
-		db:  db,
+		tbl:             tbl,
+		eventsTbl:       eventsTbl,
+		backlogTbl:      backlogTbl,
+		surfaceFormsTbl: surfaceFormsTbl,
+		coOccurrenceTbl: coOccurrenceTbl,
+		db:              db,
 	}
 }
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/keenywheels/backend/internal/processor/models"
+)
+
+// surfaceForm represents mentions of the original form of the token
+type surfaceForm struct {
+	tokenName string
+	form      string
+	mentions  int64
+}
+
+// upsertSurfaceForms adds mentions of original forms of the tokens to the counters of the day
+func (r *Repository) upsertSurfaceForms(ctx context.Context, tx pgx.Tx, date time.Time, tokens []models.TokenData) error {
+	op := "Repository.upsertSurfaceForms"
+
+	var forms []surfaceForm
+
+	for _, token := range tokens {
+		for form, mentions := range token.SurfaceForms {
+			forms = append(forms, surfaceForm{tokenName: token.TokenName, form: form, mentions: mentions})
+		}
+	}
+
+	if len(forms) == 0 {
+		return nil
+	}
+
+	// rows are locked in the same order by all transactions, so concurrent upserts can't deadlock
+	slices.SortFunc(forms, func(a, b surfaceForm) int {
+		return cmp.Or(cmp.Compare(a.tokenName, b.tokenName), cmp.Compare(a.form, b.form))
+	})
+
+	for chunk := range slices.Chunk(forms, maxBatchSize) {
+		builder := r.db.Builder.Insert(r.surfaceFormsTbl.Name).
+			Columns(
+				r.surfaceFormsTbl.Fields.TokenName,
+				r.surfaceFormsTbl.Fields.Date,
+				r.surfaceFormsTbl.Fields.SurfaceForm,
+				r.surfaceFormsTbl.Fields.Mentions,
+			).
+			Suffix(fmt.Sprintf("ON CONFLICT (%[1]s, %[2]s, %[3]s) DO UPDATE SET %[4]s = %[5]s.%[4]s + EXCLUDED.%[4]s",
+				r.surfaceFormsTbl.Fields.TokenName,
+				r.surfaceFormsTbl.Fields.Date,
+				r.surfaceFormsTbl.Fields.SurfaceForm,
+				r.surfaceFormsTbl.Fields.Mentions,
+				r.surfaceFormsTbl.Name,
+			))
+
+		for _, f := range chunk {
+			builder = builder.Values(f.tokenName, date, f.form, f.mentions)
+		}
+
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return fmt.Errorf("[%s] failed to build upsert query: %w", op, err)
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("[%s] failed to upsert surface forms: %w", op, err)
+		}
+	}
+
+	return nil
+}
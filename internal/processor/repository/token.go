@@ -2,10 +2,10 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/simhash"
 	"github.com/keenywheels/backend/internal/processor/models"
 )
 
@@ -13,17 +13,29 @@ const (
 	maxBatchSize = 1000
 )
 
-// InsertTokens inserts multiple token data records into the database
-func (r *Repository) InsertTokens(ctx context.Context, tokens []models.TokenData) error {
-	var errs []error
+// InsertTokens inserts multiple token data records of the event into the database within one transaction,
+// returns ErrAlreadyIngested if the event was already ingested, so tokens are never inserted twice
+func (r *Repository) InsertTokens(ctx context.Context, event models.IngestedEvent, tokens []models.TokenData) error {
+	op := "Repository.InsertTokens"
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// save event fingerprint first, unique constraint guarantees that only one transaction inserts tokens
+	if err := r.insertEvent(ctx, tx, event); err != nil {
+		return err
+	}
 
 	batch := make([]*models.TokenData, 0, maxBatchSize)
 	for _, token := range tokens {
 		batch = append(batch, &token)
 
 		if len(batch) >= maxBatchSize {
-			if err := r.insertBatch(ctx, batch); err != nil {
-				errs = append(errs, err) // collect errors but continue processing
+			if err := r.insertBatch(ctx, tx, batch); err != nil {
+				return err
 			}
 
 			batch = batch[:0]
@@ -31,29 +43,85 @@ func (r *Repository) InsertTokens(ctx context.Context, tokens []models.TokenData
 	}
 
 	if len(batch) > 0 {
-		if err := r.insertBatch(ctx, batch); err != nil {
-			errs = append(errs, err)
+		if err := r.insertBatch(ctx, tx, batch); err != nil {
+			return err
 		}
 	}
 
-	return errors.Join(errs...)
+	if err := r.upsertSurfaceForms(ctx, tx, event.Date, tokens); err != nil {
+		return err
+	}
+
+	if err := r.upsertCoOccurrences(ctx, tx, event.Date, tokens); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[%s] failed to commit transaction: %w", op, err)
+	}
+
+	return nil
 }
 
-// insertBatch inserts a batch of token data records into the database
-func (r *Repository) insertBatch(ctx context.Context, tokens []*models.TokenData) error {
-	op := "Repository.insertBatch"
+// insertEvent inserts the event fingerprint, returns ErrAlreadyIngested if it already exists
+func (r *Repository) insertEvent(ctx context.Context, tx pgx.Tx, event models.IngestedEvent) error {
+	op := "Repository.insertEvent"
 
-	tx, err := r.db.Pool.Begin(ctx)
+	columns := []string{
+		r.eventsTbl.Fields.Fingerprint,
+		r.eventsTbl.Fields.SiteName,
+		r.eventsTbl.Fields.Date,
+		r.eventsTbl.Fields.Duplicate,
+	}
+	values := []any{
+		event.Fingerprint,
+		event.SiteName,
+		event.Date,
+		event.Duplicate,
+	}
+
+	// bands of the fingerprint are saved to look up near-duplicates
+	if event.SimHash != nil {
+		columns = append(columns, r.eventsTbl.Fields.SimHash)
+		values = append(values, int64(*event.SimHash))
+
+		for i, band := range simhash.Split(*event.SimHash) {
+			columns = append(columns, r.eventsTbl.Fields.SimHashBands[i])
+			values = append(values, int32(band))
+		}
+	}
+
+	query, args, err := r.db.Builder.Insert(r.eventsTbl.Name).
+		Columns(columns...).
+		Values(values...).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
 	if err != nil {
-		return fmt.Errorf("[%s] failed to begin transaction: %w", op, err)
+		return fmt.Errorf("[%s] failed to build insert query: %w", op, err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to insert event: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[%s] event %s: %w", op, event.Fingerprint, ErrAlreadyIngested)
 	}
-	defer tx.Rollback(ctx)
+
+	return nil
+}
+
+// insertBatch inserts a batch of token data records into the database,
+// tokens with pending sentiment are also put into sentiment backlog
+func (r *Repository) insertBatch(ctx context.Context, tx pgx.Tx, tokens []*models.TokenData) error {
+	op := "Repository.insertBatch"
 
 	batch := &pgx.Batch{}
 
 	for _, token := range tokens {
 		// create query
-		query, args, err := r.db.Builder.Insert(r.tbl.Name).
+		builder := r.db.Builder.Insert(r.tbl.Name).
 			Columns(
 				r.tbl.Fields.TokenName,
 				r.tbl.Fields.Interest,
@@ -61,6 +129,12 @@ func (r *Repository) insertBatch(ctx context.Context, tokens []*models.TokenData
 				r.tbl.Fields.SiteName,
 				r.tbl.Fields.Date,
 				r.tbl.Fields.Sentiment,
+				r.tbl.Fields.SentimentPending,
+				r.tbl.Fields.SentimentScore,
+				r.tbl.Fields.SentimentConfidence,
+				r.tbl.Fields.PositiveMentions,
+				r.tbl.Fields.NeutralMentions,
+				r.tbl.Fields.NegativeMentions,
 			).
 			Values(
 				token.TokenName,
@@ -69,8 +143,20 @@ func (r *Repository) insertBatch(ctx context.Context, tokens []*models.TokenData
 				token.SiteName,
 				token.Date,
 				token.Sentiment,
-			).
-			ToSql()
+				token.SentimentPending,
+				token.SentimentScore,
+				token.SentimentConfidence,
+				token.PositiveMentions,
+				token.NeutralMentions,
+				token.NegativeMentions,
+			)
+
+		// id of pending token is needed for backlog
+		if token.SentimentPending {
+			builder = builder.Suffix("RETURNING " + r.tbl.Fields.TokenID)
+		}
+
+		query, args, err := builder.ToSql()
 		if err != nil {
 			return fmt.Errorf("[%s] failed to build insert query: %w", op, err)
 		}
@@ -80,9 +166,30 @@ func (r *Repository) insertBatch(ctx context.Context, tokens []*models.TokenData
 
 	res := tx.SendBatch(ctx, batch)
 
+	var backlog []models.SentimentBacklogItem
+
+	for _, token := range tokens {
+		if !token.SentimentPending {
+			if _, err := res.Exec(); err != nil {
+				res.Close()
+				return fmt.Errorf("[%s] failed to execute batch insert: %w", op, err)
+			}
+
+			continue
+		}
+
+		item := models.SentimentBacklogItem{Context: token.Context}
+		if err := res.QueryRow().Scan(&item.TokenID); err != nil {
+			res.Close()
+			return fmt.Errorf("[%s] failed to execute batch insert: %w", op, err)
+		}
+
+		backlog = append(backlog, item)
+	}
+
 	if err := res.Close(); err != nil {
 		return fmt.Errorf("[%s] failed to execute batch insert: %w", op, err)
 	}
 
-	return tx.Commit(ctx)
+	return r.insertBacklog(ctx, tx, backlog)
 }
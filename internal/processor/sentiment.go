@@ -0,0 +1,119 @@
+package processor
+
+import (
+	"fmt"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	llmbreaker "github.com/keenywheels/backend/internal/pkg/client/llm/breaker"
+	llmcache "github.com/keenywheels/backend/internal/pkg/client/llm/cache"
+	llmfallback "github.com/keenywheels/backend/internal/pkg/client/llm/fallback"
+	"github.com/keenywheels/backend/internal/pkg/client/llm/lexicon"
+	"github.com/keenywheels/backend/internal/pkg/client/llm/openai"
+	"github.com/keenywheels/backend/internal/processor/service"
+	"github.com/keenywheels/backend/pkg/redis"
+)
+
+// sentiment analysis providers
+const (
+	providerSntmnt  = "sntmnt"
+	providerOpenAI  = "openai"
+	providerLexicon = "lexicon"
+)
+
+// getSentimentClient creates sentiment analysis client based on config,
+// returned func releases resources of the client
+func (app *App) getSentimentClient() (service.IClientLLM, func(), error) {
+	var (
+		cfg     = app.cfg.App.Clients
+		closers []func()
+	)
+
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	client, err := app.getSentimentProvider(cfg.Sentiment.Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// put sentiment cache in front of the provider
+	if cfg.LLMCache.Enabled {
+		cacheOpts := []llmcache.Option{
+			llmcache.WithLogger(app.logger),
+		}
+
+		// redis tier is optional
+		if app.cfg.App.Redis.Addr != "" {
+			rdb, err := redis.New(&app.cfg.App.Redis)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create redis connection: %w", err)
+			}
+
+			closers = append(closers, func() { rdb.Close() })
+			cacheOpts = append(cacheOpts, llmcache.WithRedis(rdb))
+		}
+
+		cache, err := llmcache.New(client, cfg.LLMCache, cacheOpts...)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to create sentiment cache: %w", err)
+		}
+
+		closers = append(closers, func() {
+			app.logger.Infof("sentiment cache stats: %+v", cache.Stats())
+		})
+
+		client = cache
+	}
+
+	// use fallback provider if the main one fails
+	if cfg.Sentiment.Fallback != "" && cfg.Sentiment.Fallback != cfg.Sentiment.Provider {
+		fallback, err := app.getSentimentProvider(cfg.Sentiment.Fallback)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to create fallback: %w", err)
+		}
+
+		client = llmfallback.New(client, fallback, llmfallback.WithLogger(app.logger))
+	}
+
+	return client, closeAll, nil
+}
+
+// getSentimentProvider creates sentiment analysis provider by its name
+func (app *App) getSentimentProvider(name string) (service.IClientLLM, error) {
+	cfg := app.cfg.App.Clients
+
+	var client service.IClientLLM
+
+	switch name {
+	case "", providerSntmnt:
+		client = llm.NewClient(&cfg.LLM)
+	case providerOpenAI:
+		openaiClient, err := openai.NewClient(cfg.OpenAI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create openai client: %w", err)
+		}
+
+		client = openaiClient
+	case providerLexicon:
+		analyzer, err := lexicon.New(cfg.Lexicon)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create lexicon analyzer: %w", err)
+		}
+
+		return analyzer, nil
+	default:
+		return nil, fmt.Errorf("unknown sentiment provider %q", name)
+	}
+
+	// fail fast while remote provider is down, tokens are saved with pending sentiment
+	if cfg.LLMBreaker.Enabled {
+		client = llmbreaker.New(client, cfg.LLMBreaker, llmbreaker.WithLogger(app.logger))
+	}
+
+	return client, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/internal/pkg/client/llm/breaker"
+	"github.com/keenywheels/backend/internal/processor/models"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+)
+
+// backfillSentimentTask analyzes sentiment of tokens saved while llm was unavailable
+func (s *Service) backfillSentimentTask(ctx context.Context) error {
+	var (
+		op  = "Service.backfillSentimentTask"
+		log = ctxutils.GetLogger(ctx)
+	)
+
+	backlog, err := s.repo.GetSentimentBacklog(ctx, s.cfg.Backfill.BatchSize)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to get sentiment backlog: %w", op, err)
+	}
+
+	if len(backlog) == 0 {
+		return nil
+	}
+
+	// token id is used as token, so results are mapped back to rows
+	req := &llm.BatchSentimentAnalysisRequest{
+		Items: make([]llm.BatchSentimentItem, 0, len(backlog)),
+	}
+
+	for _, item := range backlog {
+		req.Items = append(req.Items, llm.BatchSentimentItem{
+			Token:   strconv.FormatInt(item.TokenID, 10),
+			Context: item.Context,
+		})
+	}
+
+	resp, err := s.llm.BatchSentimentAnalysis(ctx, req)
+	if err != nil {
+		// llm is still unavailable -> try next time
+		if errors.Is(err, breaker.ErrOpen) {
+			log.Infof("[%s] llm is unavailable, %d tokens are still pending", op, len(backlog))
+			return nil
+		}
+
+		return fmt.Errorf("[%s] failed to analyze sentiment: %w", op, err)
+	}
+
+	var (
+		results    = resp.ByToken()
+		sentiments = make([]models.TokenSentiment, 0, len(backlog))
+		failed     []int64
+		dead       []int64
+	)
+
+	for _, item := range backlog {
+		res, ok := results[strconv.FormatInt(item.TokenID, 10)]
+		if !ok || res.Err() != nil {
+			// llm became unavailable during backfill -> token is still pending, it is not an attempt
+			if ok && errors.Is(res.Err(), breaker.ErrOpen) {
+				continue
+			}
+
+			// poison contexts would fail forever, so the token is given up after max attempts
+			if item.Attempts+1 >= s.cfg.Backfill.MaxAttempts {
+				dead = append(dead, item.TokenID)
+				continue
+			}
+
+			failed = append(failed, item.TokenID)
+			continue
+		}
+
+		sentiment := newSentimentResult(res.Response(), item.Context)
+
+		sentiments = append(sentiments, models.TokenSentiment{
+			TokenID:             item.TokenID,
+			Sentiment:           sentiment.value,
+			SentimentScore:      sentiment.score,
+			SentimentConfidence: sentiment.confidence,
+			PositiveMentions:    sentiment.mentions.Positive,
+			NeutralMentions:     sentiment.mentions.Neutral,
+			NegativeMentions:    sentiment.mentions.Negative,
+		})
+	}
+
+	if err := s.repo.ResolveSentiments(ctx, sentiments); err != nil {
+		return fmt.Errorf("[%s] failed to save sentiments: %w", op, err)
+	}
+
+	if err := s.repo.IncBacklogAttempts(ctx, failed); err != nil {
+		return fmt.Errorf("[%s] failed to update failed backlog items: %w", op, err)
+	}
+
+	if err := s.repo.DeleteBacklog(ctx, dead); err != nil {
+		return fmt.Errorf("[%s] failed to delete dead backlog items: %w", op, err)
+	}
+
+	if len(dead) > 0 {
+		log.Warnf("[%s] sentiment of %d tokens failed %d times, they are removed from backlog: %v",
+			op, len(dead), s.cfg.Backfill.MaxAttempts, dead,
+		)
+	}
+
+	log.Infof("[%s] backfilled sentiment of %d tokens, failed=%d, dead=%d", op, len(sentiments), len(failed), len(dead))
+
+	return nil
+}
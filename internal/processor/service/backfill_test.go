@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/internal/pkg/client/llm/breaker"
+	"github.com/keenywheels/backend/internal/processor/models"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+	"github.com/keenywheels/backend/pkg/logger/zap"
+)
+
+// backlogRepo stand-in of repository which records changes of the backlog
+type backlogRepo struct {
+	IRepository
+
+	backlog  []models.SentimentBacklogItem
+	resolved []int64
+	retried  []int64
+	deleted  []int64
+}
+
+func (r *backlogRepo) GetSentimentBacklog(_ context.Context, _ uint64) ([]models.SentimentBacklogItem, error) {
+	return r.backlog, nil
+}
+
+func (r *backlogRepo) ResolveSentiments(_ context.Context, sentiments []models.TokenSentiment) error {
+	for _, s := range sentiments {
+		r.resolved = append(r.resolved, s.TokenID)
+	}
+
+	return nil
+}
+
+func (r *backlogRepo) IncBacklogAttempts(_ context.Context, tokenIDs []int64) error {
+	r.retried = append(r.retried, tokenIDs...)
+	return nil
+}
+
+func (r *backlogRepo) DeleteBacklog(_ context.Context, tokenIDs []int64) error {
+	r.deleted = append(r.deleted, tokenIDs...)
+	return nil
+}
+
+// stubLLM analyzes contexts with results set by context, other contexts are positive
+type stubLLM struct {
+	IClientLLM
+
+	errs map[string]error
+}
+
+func (c *stubLLM) BatchSentimentAnalysis(
+	_ context.Context,
+	req *llm.BatchSentimentAnalysisRequest,
+) (*llm.BatchSentimentAnalysisResponse, error) {
+	resp := &llm.BatchSentimentAnalysisResponse{}
+
+	for _, item := range req.Items {
+		if err, ok := c.errs[item.Context]; ok {
+			resp.Results = append(resp.Results, llm.NewBatchSentimentError(item.Token, err))
+			continue
+		}
+
+		res := llm.NewBatchSentimentResult(item.Token, &llm.SentimentAnalysisResponse{Sentiment: 1})
+		resp.Results = append(resp.Results, res)
+	}
+
+	return resp, nil
+}
+
+func TestBackfillSentimentMaxAttempts(t *testing.T) {
+	repo := &backlogRepo{backlog: []models.SentimentBacklogItem{
+		{TokenID: 1, Context: "good", Attempts: 9},
+		{TokenID: 2, Context: "poison", Attempts: 0},
+		{TokenID: 3, Context: "poison", Attempts: 9},
+		{TokenID: 4, Context: "outage", Attempts: 9},
+	}}
+
+	s := &Service{
+		repo: repo,
+		llm: &stubLLM{errs: map[string]error{
+			"poison": errors.New("context can not be analyzed"),
+			"outage": breaker.ErrOpen,
+		}},
+		cfg: Config{Backfill: BackfillConfig{BatchSize: 10, MaxAttempts: 10}},
+	}
+
+	ctx := ctxutils.SetLogger(context.Background(), zap.New(zap.LogPath(filepath.Join(t.TempDir(), "app.log"))))
+
+	if err := s.backfillSentimentTask(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(repo.resolved, []int64{1}) {
+		t.Fatalf("expected token 1 to be resolved, got %v", repo.resolved)
+	}
+
+	if !slices.Equal(repo.retried, []int64{2}) {
+		t.Fatalf("expected token 2 to be retried, got %v", repo.retried)
+	}
+
+	// token 4 was not analyzed while circuit is open, so its attempt is not counted
+	if !slices.Equal(repo.deleted, []int64{3}) {
+		t.Fatalf("expected token 3 to be removed after max attempts, got %v", repo.deleted)
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/simhash"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/stages"
+)
+
+// sentiment failure policies
+const (
+	// FailurePolicyNeutral sets neutral sentiment for the token if analysis failed
+	FailurePolicyNeutral = "neutral"
+	// FailurePolicySkip skips the token if analysis failed
+	FailurePolicySkip = "skip"
+	// FailurePolicyFail fails the whole message if analysis failed
+	FailurePolicyFail = "fail"
+)
+
+// sentiment analysis modes
+const (
+	// SentimentModeBatch sends contexts of all message tokens in batch requests
+	SentimentModeBatch = "batch"
+	// SentimentModeSingle sends a request per token
+	SentimentModeSingle = "single"
+)
+
+// near-duplicate policies
+const (
+	// DedupPolicySkip saves near-duplicate message without tokens
+	DedupPolicySkip = "skip"
+	// DedupPolicyDownweight multiplies interest of near-duplicate message tokens by weight
+	DedupPolicyDownweight = "downweight"
+)
+
+// default values
+const (
+	defaultSentimentMode        = SentimentModeBatch
+	defaultSentimentConcurrency = 8
+	defaultFailurePolicy        = FailurePolicyNeutral
+	neutralSentiment            = int16(0)
+	defaultBackfillInterval     = time.Minute
+	defaultBackfillBatchSize    = 500
+	defaultBackfillMaxAttempts  = 10
+	defaultDedupWindow          = 72 * time.Hour
+	defaultDedupMaxDistance     = 3
+	defaultDedupPolicy          = DedupPolicyDownweight
+	defaultDedupWeight          = 0.1
+	defaultDedupMinTokens       = 5
+)
+
+// SentimentConfig holds sentiment analysis configuration
+type SentimentConfig struct {
+	Mode          string `mapstructure:"mode"`
+	Concurrency   int    `mapstructure:"concurrency"`
+	FailurePolicy string `mapstructure:"failure_policy"`
+}
+
+// BackfillConfig holds configuration of pending sentiment backfill job
+type BackfillConfig struct {
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize uint64        `mapstructure:"batch_size"`
+
+	// MaxAttempts is the number of failed attempts after which the token is removed from the backlog
+	MaxAttempts int `mapstructure:"max_attempts"`
+}
+
+// DedupConfig holds configuration of near-duplicate messages detection,
+// messages are near-duplicates if their SimHash differs in at most MaxDistance bits,
+// MaxDistance is a pointer, because zero distance (exact SimHash match) is a valid value
+type DedupConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Window      time.Duration `mapstructure:"window"`
+	MaxDistance *int          `mapstructure:"max_distance"`
+	Policy      string        `mapstructure:"policy"`
+	Weight      float64       `mapstructure:"weight"`
+	MinTokens   int           `mapstructure:"min_tokens"`
+}
+
+// Config holds service configuration
+type Config struct {
+	Sentiment SentimentConfig       `mapstructure:"sentiment"`
+	Backfill  BackfillConfig        `mapstructure:"backfill"`
+	Dedup     DedupConfig           `mapstructure:"dedup"`
+	Tokenizer stages.PipelineConfig `mapstructure:"tokenizer"`
+}
+
+// withDefaults returns config with zero values replaced with defaults
+func (c Config) withDefaults() (Config, error) {
+	if c.Sentiment.Concurrency <= 0 {
+		c.Sentiment.Concurrency = defaultSentimentConcurrency
+	}
+
+	if c.Backfill.Interval <= 0 {
+		c.Backfill.Interval = defaultBackfillInterval
+	}
+
+	if c.Backfill.BatchSize == 0 {
+		c.Backfill.BatchSize = defaultBackfillBatchSize
+	}
+
+	if c.Backfill.MaxAttempts <= 0 {
+		c.Backfill.MaxAttempts = defaultBackfillMaxAttempts
+	}
+
+	if c.Dedup.Window <= 0 {
+		c.Dedup.Window = defaultDedupWindow
+	}
+
+	if c.Dedup.MinTokens <= 0 {
+		c.Dedup.MinTokens = defaultDedupMinTokens
+	}
+
+	if c.Dedup.Weight <= 0 {
+		c.Dedup.Weight = defaultDedupWeight
+	}
+
+	if c.Dedup.Weight > 1 {
+		return c, fmt.Errorf("dedup weight %v is greater than 1", c.Dedup.Weight)
+	}
+
+	// candidates are looked up by bands, so distance is limited by their number
+	switch {
+	case c.Dedup.MaxDistance == nil:
+		maxDistance := defaultDedupMaxDistance
+		c.Dedup.MaxDistance = &maxDistance
+	case *c.Dedup.MaxDistance < 0:
+		return c, fmt.Errorf("dedup max distance %d is negative", *c.Dedup.MaxDistance)
+	case *c.Dedup.MaxDistance >= simhash.Bands:
+		return c, fmt.Errorf("dedup max distance %d is greater than %d", *c.Dedup.MaxDistance, simhash.Bands-1)
+	}
+
+	switch c.Dedup.Policy {
+	case "":
+		c.Dedup.Policy = defaultDedupPolicy
+	case DedupPolicySkip, DedupPolicyDownweight:
+	default:
+		return c, fmt.Errorf("unknown dedup policy %q", c.Dedup.Policy)
+	}
+
+	switch c.Sentiment.Mode {
+	case "":
+		c.Sentiment.Mode = defaultSentimentMode
+	case SentimentModeBatch, SentimentModeSingle:
+	default:
+		return c, fmt.Errorf("unknown sentiment mode %q", c.Sentiment.Mode)
+	}
+
+	switch c.Sentiment.FailurePolicy {
+	case "":
+		c.Sentiment.FailurePolicy = defaultFailurePolicy
+	case FailurePolicyNeutral, FailurePolicySkip, FailurePolicyFail:
+	default:
+		return c, fmt.Errorf("unknown sentiment failure policy %q", c.Sentiment.FailurePolicy)
+	}
+
+	return c, nil
+}
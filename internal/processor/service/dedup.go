@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	tokenizerbase "github.com/keenywheels/backend/internal/pkg/tokenizer"
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/simhash"
+	"github.com/keenywheels/backend/internal/processor/models"
+	"github.com/keenywheels/backend/internal/processor/repository"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// dedup results of the message
+const (
+	dedupResultUnique    = "unique"
+	dedupResultDuplicate = "duplicate"
+)
+
+// detectDuplicate computes SimHash of the message tokens and marks the event as duplicate if near-duplicate
+// message was ingested within the window, short messages are not fingerprinted
+func (s *Service) detectDuplicate(ctx context.Context, event *models.IngestedEvent, tokens []tokenizerbase.Token) error {
+	var (
+		op  = "Service.detectDuplicate"
+		log = ctxutils.GetLogger(ctx)
+	)
+
+	if !s.cfg.Dedup.Enabled {
+		return nil
+	}
+
+	// normalized words are used, so copies with different case and punctuation are matched
+	features := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t.IsFiltered() || t.Kind() == tokenizerbase.KindPhrase {
+			continue
+		}
+
+		features = append(features, t.Target)
+	}
+
+	if len(features) < s.cfg.Dedup.MinTokens {
+		return nil
+	}
+
+	hash := simhash.Compute(features)
+	event.SimHash = &hash
+
+	original, err := s.repo.FindNearDuplicate(ctx, &repository.FindNearDuplicateParams{
+		SimHash:     hash,
+		From:        event.Date.Add(-s.cfg.Dedup.Window),
+		To:          event.Date.Add(s.cfg.Dedup.Window),
+		MaxDistance: *s.cfg.Dedup.MaxDistance,
+	})
+	if err != nil {
+		return fmt.Errorf("[%s] failed to find near-duplicate: %w", op, err)
+	}
+
+	result := dedupResultUnique
+	if original != "" && original != event.Fingerprint {
+		log.Infof("[%s] event %s from %s is near-duplicate of %s -> policy %s",
+			op, event.Fingerprint, event.SiteName, original, s.cfg.Dedup.Policy,
+		)
+
+		event.Duplicate = true
+		result = dedupResultDuplicate
+	}
+
+	s.dedupMessages.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("site", event.SiteName),
+		attribute.String("result", result),
+	))
+
+	return nil
+}
+
+// downweightInterest multiplies interest and co-occurrences of the tokens by dedup weight
+func (s *Service) downweightInterest(tokens []models.TokenData) {
+	for i := range tokens {
+		tokens[i].Interest = s.downweight(tokens[i].Interest)
+
+		for related, mentions := range tokens[i].CoOccurrences {
+			tokens[i].CoOccurrences[related] = s.downweight(mentions)
+		}
+	}
+}
+
+// downweight multiplies the value by dedup weight, positive values are never rounded to zero,
+// because values are divided by their medians in vixarapi
+func (s *Service) downweight(value int64) int64 {
+	if value <= 0 {
+		return value
+	}
+
+	return max(int64(math.Round(float64(value)*s.cfg.Dedup.Weight)), 1)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/keenywheels/backend/internal/processor/models"
+)
+
+func TestDownweightInterest(t *testing.T) {
+	s := &Service{cfg: Config{Dedup: DedupConfig{Weight: 0.1}}}
+
+	tokens := []models.TokenData{{
+		Interest:      1,
+		CoOccurrences: map[string]int64{"a": 1, "b": 30},
+	}}
+
+	s.downweightInterest(tokens)
+
+	// single mentions are not rounded to zero, so medians of the day stay positive
+	if tokens[0].Interest != 1 {
+		t.Fatalf("expected interest of 1, got %d", tokens[0].Interest)
+	}
+
+	if got := tokens[0].CoOccurrences; got["a"] != 1 || got["b"] != 3 {
+		t.Fatalf("unexpected co-occurrences: %v", got)
+	}
+}
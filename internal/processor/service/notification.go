@@ -79,7 +79,12 @@ func (s *Service) notifyWithEmail(ctx context.Context, event *models.Notificatio
 			perc *= -1 // take abs to display in the template
 		}
 
-		// TODO: подумать, что можно сделать с тем, что в сообщение токен в денормализованном виде
+		// token is a stem, so readable form is displayed if it is known
+		token := event.DisplayName
+		if token == "" {
+			token = event.Token
+		}
+
 		err = templates.ExecuteTemplate(&body, fmt.Sprintf("%s.tmpl", event.Type), struct {
 			Name             string
 			Token            string
@@ -90,7 +95,7 @@ func (s *Service) notifyWithEmail(ctx context.Context, event *models.Notificatio
 			ScanTime         string
 		}{
 			Name:             event.Username,
-			Token:            event.Token,
+			Token:            token,
 			Change:           change,
 			Percentage:       perc,
 			CurrentInterest:  event.CurrentInterest,
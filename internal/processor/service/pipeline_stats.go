@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tokenizerbase "github.com/keenywheels/backend/internal/pkg/tokenizer"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// statuses of tokens passed through the tokenizer stage
+const (
+	stageTokensIn       = "in"
+	stageTokensFiltered = "filtered"
+	stageTokensFailed   = "failed"
+)
+
+// recordPipelineStats logs statistics of the tokenizer stages for the message and exports them as metrics,
+// stages with failed tokens are logged as warnings, so broken stemmer or filter is noticed
+func (s *Service) recordPipelineStats(ctx context.Context, site string, stats []tokenizerbase.StageStats) {
+	var (
+		op  = "Service.recordPipelineStats"
+		log = ctxutils.GetLogger(ctx)
+	)
+
+	var summary strings.Builder
+
+	for _, st := range stats {
+		stageAttr := attribute.String("stage", st.Name)
+		siteAttr := attribute.String("site", site)
+
+		s.stageTokens.Add(ctx, int64(st.In), metric.WithAttributes(
+			stageAttr, siteAttr, attribute.String("status", stageTokensIn),
+		))
+		s.stageTokens.Add(ctx, int64(st.Filtered), metric.WithAttributes(
+			stageAttr, siteAttr, attribute.String("status", stageTokensFiltered),
+		))
+		s.stageTokens.Add(ctx, int64(st.Errors), metric.WithAttributes(
+			stageAttr, siteAttr, attribute.String("status", stageTokensFailed),
+		))
+		s.stageDuration.Record(ctx, st.Elapsed.Seconds(), metric.WithAttributes(stageAttr))
+
+		if st.Errors > 0 {
+			log.Warnf("[%s] stage %s failed on %d of %d tokens from %s", op, st.Name, st.Errors, st.In, site)
+		}
+
+		fmt.Fprintf(&summary, "%s: in=%d filtered=%d errors=%d elapsed=%s; ",
+			st.Name, st.In, st.Filtered, st.Errors, st.Elapsed,
+		)
+	}
+
+	log.Debugf("[%s] tokenizer stages for message from %s: %s", op, site, summary.String())
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/google/uuid"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+)
+
+// StartScheduler starts periodic jobs of the service
+func (s *Service) StartScheduler(ctx context.Context) error {
+	if err := s.initScheduler(ctx); err != nil {
+		return fmt.Errorf("failed to init scheduler: %w", err)
+	}
+
+	s.scheduler.Start()
+
+	return nil
+}
+
+// CloseScheduler stops the scheduler
+func (s *Service) CloseScheduler() error {
+	return s.scheduler.Shutdown()
+}
+
+// initScheduler initializes the scheduler for periodic tasks
+func (s *Service) initScheduler(ctx context.Context) error {
+	var (
+		log = ctxutils.GetLogger(ctx)
+	)
+
+	_, err := s.scheduler.NewJob(
+		gocron.DurationJob(s.cfg.Backfill.Interval),
+		gocron.NewTask(s.backfillSentimentTask),
+		gocron.WithContext(ctx),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithEventListeners(
+			gocron.AfterJobRunsWithError(func(jobID uuid.UUID, jobName string, err error) {
+				log.Errorf("job %s failed: %v", jobName, err)
+			}),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to init job: %w", err)
+	}
+
+	return nil
+}
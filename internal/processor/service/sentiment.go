@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/client/llm"
+	"github.com/keenywheels/backend/internal/pkg/client/llm/breaker"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+	"github.com/keenywheels/backend/pkg/logger"
+	"golang.org/x/sync/errgroup"
+)
+
+// contextSeparator separates occurrences of the token in its context
+const contextSeparator = ";"
+
+// sentimentResult represents result of token sentiment analysis
+type sentimentResult struct {
+	value      int16
+	score      float64
+	confidence *float64 // nil if provider does not return confidence
+	mentions   llm.SentimentMentions
+
+	// pending is set if llm is unavailable and sentiment should be backfilled later
+	pending bool
+}
+
+// newSentimentResult creates result from the provider response, details which provider did not return
+// are derived from sentiment: score is equal to it and every mention in the context gets its polarity
+func newSentimentResult(resp *llm.SentimentAnalysisResponse, context string) sentimentResult {
+	res := sentimentResult{
+		value:      resp.Sentiment,
+		score:      float64(resp.Sentiment),
+		confidence: resp.Confidence,
+	}
+
+	if resp.Score != nil {
+		res.score = *resp.Score
+	}
+
+	if resp.Mentions != nil {
+		res.mentions = *resp.Mentions
+		return res
+	}
+
+	mentions := int64(max(strings.Count(context, contextSeparator), 1))
+
+	switch {
+	case resp.Sentiment > neutralSentiment:
+		res.mentions.Positive = mentions
+	case resp.Sentiment < neutralSentiment:
+		res.mentions.Negative = mentions
+	default:
+		res.mentions.Neutral = mentions
+	}
+
+	return res
+}
+
+// analyzeSentiments analyzes sentiment of tokens and applies failure policy to the failed ones,
+// returns sentiments by token name
+func (s *Service) analyzeSentiments(
+	ctx context.Context,
+	tokensContext map[string]*strings.Builder,
+) (map[string]sentimentResult, error) {
+	var (
+		log        = ctxutils.GetLogger(ctx)
+		start      = time.Now()
+		sentiments map[string]sentimentResult
+		failed     int
+		err        error
+	)
+
+	switch s.cfg.Sentiment.Mode {
+	case SentimentModeSingle:
+		sentiments, failed, err = s.analyzeSentimentsSingle(ctx, log, tokensContext)
+	default:
+		sentiments, failed, err = s.analyzeSentimentsBatch(ctx, log, tokensContext)
+	}
+
+	log.Infof("analyzed sentiment of %d tokens in %s: failed=%d, mode=%s, policy=%s",
+		len(tokensContext), time.Since(start), failed, s.cfg.Sentiment.Mode, s.cfg.Sentiment.FailurePolicy,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sentiments, nil
+}
+
+// analyzeSentimentsBatch analyzes sentiment of all tokens with batch requests
+func (s *Service) analyzeSentimentsBatch(
+	ctx context.Context,
+	log logger.Logger,
+	tokensContext map[string]*strings.Builder,
+) (map[string]sentimentResult, int, error) {
+	var (
+		failed     int
+		sentiments = make(map[string]sentimentResult, len(tokensContext))
+		req        = &llm.BatchSentimentAnalysisRequest{
+			Items: make([]llm.BatchSentimentItem, 0, len(tokensContext)),
+		}
+	)
+
+	for tokenName, tokenCtx := range tokensContext {
+		req.Items = append(req.Items, llm.BatchSentimentItem{
+			Token:   tokenName,
+			Context: tokenCtx.String(),
+		})
+	}
+
+	if len(req.Items) == 0 {
+		return sentiments, 0, nil
+	}
+
+	resp, respErr := s.llm.BatchSentimentAnalysis(ctx, req)
+
+	var results map[string]llm.BatchSentimentResult
+	if respErr == nil {
+		results = resp.ByToken()
+	}
+
+	for tokenName := range tokensContext {
+		// whole request failed -> every token is failed
+		err := respErr
+
+		if err == nil {
+			res, ok := results[tokenName]
+
+			switch {
+			case !ok:
+				err = errors.New("no result in batch response")
+			case res.Err() != nil:
+				err = res.Err()
+			default:
+				sentiments[tokenName] = newSentimentResult(res.Response(), tokensContext[tokenName].String())
+				continue
+			}
+		}
+
+		failed++
+
+		if err := s.applyFailurePolicy(log, sentiments, tokenName, err); err != nil {
+			return nil, failed, err
+		}
+	}
+
+	return sentiments, failed, nil
+}
+
+// analyzeSentimentsSingle analyzes sentiment of tokens with a request per token
+func (s *Service) analyzeSentimentsSingle(
+	ctx context.Context,
+	log logger.Logger,
+	tokensContext map[string]*strings.Builder,
+) (map[string]sentimentResult, int, error) {
+	var (
+		mu         sync.Mutex
+		failed     int
+		sentiments = make(map[string]sentimentResult, len(tokensContext))
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(s.cfg.Sentiment.Concurrency)
+
+	for tokenName, tokenCtx := range tokensContext {
+		g.Go(func() error {
+			resp, err := s.llm.SentimentAnalysis(gCtx, &llm.SentimentAnalysisRequest{
+				Context: tokenCtx.String(),
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err == nil {
+				sentiments[tokenName] = newSentimentResult(resp, tokenCtx.String())
+				return nil
+			}
+
+			failed++
+
+			return s.applyFailurePolicy(log, sentiments, tokenName, err)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, failed, err
+	}
+
+	return sentiments, failed, nil
+}
+
+// applyFailurePolicy handles failed sentiment analysis of the token according to the failure policy,
+// returns error only if the whole message should fail
+func (s *Service) applyFailurePolicy(
+	log logger.Logger,
+	sentiments map[string]sentimentResult,
+	tokenName string,
+	err error,
+) error {
+	// llm is unavailable -> token is saved with pending sentiment regardless of policy
+	if errors.Is(err, breaker.ErrOpen) {
+		sentiments[tokenName] = sentimentResult{value: neutralSentiment, pending: true}
+		return nil
+	}
+
+	switch s.cfg.Sentiment.FailurePolicy {
+	case FailurePolicyFail:
+		return fmt.Errorf("failed to analyze sentiment for token %s: %w", tokenName, err)
+	case FailurePolicySkip:
+		log.Warnf("failed to analyze sentiment for token %s -> skip it: %v", tokenName, err)
+	default:
+		log.Warnf("failed to analyze sentiment for token %s -> set neutral: %v", tokenName, err)
+		sentiments[tokenName] = sentimentResult{value: neutralSentiment}
+	}
+
+	return nil
+}
@@ -2,28 +2,46 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/go-co-op/gocron/v2"
 	"github.com/keenywheels/backend/internal/pkg/client/llm"
 	"github.com/keenywheels/backend/internal/pkg/tokenizer"
 	"github.com/keenywheels/backend/internal/pkg/tokenizer/metrics"
-	"github.com/keenywheels/backend/internal/pkg/tokenizer/pkg/stemmer"
 	"github.com/keenywheels/backend/internal/pkg/tokenizer/stages"
 	"github.com/keenywheels/backend/internal/processor/models"
+	"github.com/keenywheels/backend/internal/processor/repository"
 	"github.com/keenywheels/backend/pkg/mailer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 )
 
 const (
-	interestMetricKey = "interest"
+	interestMetricKey     = metrics.InterestMetricName
+	coOccurrenceMetricKey = metrics.CoOccurrenceMetricName
+
+	// meterName is the instrumentation name of service metrics
+	meterName = "github.com/keenywheels/backend/internal/processor/service"
 )
 
 // IClientLLM define the intervace for LLM client interactions
 type IClientLLM interface {
 	SentimentAnalysis(ctx context.Context, req *llm.SentimentAnalysisRequest) (*llm.SentimentAnalysisResponse, error)
+	BatchSentimentAnalysis(
+		ctx context.Context,
+		req *llm.BatchSentimentAnalysisRequest,
+	) (*llm.BatchSentimentAnalysisResponse, error)
 }
 
 // IRepository defines the interface for repository layer interactions
 type IRepository interface {
-	InsertTokens(ctx context.Context, tokens []models.TokenData) error
+	IsIngested(ctx context.Context, fingerprint string) (bool, error)
+	FindNearDuplicate(ctx context.Context, params *repository.FindNearDuplicateParams) (string, error)
+	InsertTokens(ctx context.Context, event models.IngestedEvent, tokens []models.TokenData) error
+	GetSentimentBacklog(ctx context.Context, limit uint64) ([]models.SentimentBacklogItem, error)
+	ResolveSentiments(ctx context.Context, sentiments []models.TokenSentiment) error
+	IncBacklogAttempts(ctx context.Context, tokenIDs []int64) error
+	DeleteBacklog(ctx context.Context, tokenIDs []int64) error
 }
 
 // Service struct for service layer logic
@@ -31,6 +49,15 @@ type Service struct {
 	repo   IRepository
 	llm    IClientLLM
 	mailer mailer.Mailer
+
+	scheduler gocron.Scheduler
+	pipeline  *stages.PipelineFactory
+
+	dedupMessages metric.Int64Counter
+	stageTokens   metric.Int64Counter
+	stageDuration metric.Float64Histogram
+
+	cfg Config
 }
 
 // New creates a new instance of Service
@@ -38,34 +65,74 @@ func New(
 	repo IRepository,
 	llm IClientLLM,
 	mailer mailer.Mailer,
-) *Service {
-	return &Service{
-		repo:   repo,
-		llm:    llm,
-		mailer: mailer,
+	cfg Config,
+) (*Service, error) {
+	cfg, err := cfg.withDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid service config: %w", err)
 	}
-}
 
-// metricsRegistry is a type alias for a map of metric names to Metric instances
-type metricsRegistry map[string]metrics.Metric
+	pipeline, err := stages.NewPipelineFactory(cfg.Tokenizer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tokenizer config: %w", err)
+	}
 
-// getTokenizer initializes and returns a tokenizer pipeline with metrics registry
-func getTokenizer() (*tokenizer.Pipeline, metricsRegistry) {
-	interest := metrics.NewInterestMetric()
+	// interest is required for every token
+	if !pipeline.HasMetric(interestMetricKey) {
+		return nil, fmt.Errorf("invalid tokenizer config: metric %s is not collected", interestMetricKey)
+	}
 
-	registry := metricsRegistry{
-		interestMetricKey: interest,
-		// add more metrics here if needed
+	scheduler, err := gocron.NewScheduler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduler: %w", err)
 	}
 
-	stgs := []tokenizer.PipelineStage{
-		stages.NewNormalizerStage(),
-		stages.NewFilterStage(stages.DefaultTokenMinLength),
-		stages.NewStemmerStage(stemmer.DefaultStemmer),
-		stages.NewMetricStage([]metrics.Metric{
-			interest,
-		}...),
+	meter := otel.GetMeterProvider().Meter(meterName)
+
+	dedupMessages, err := meter.Int64Counter(
+		"processor.dedup.messages",
+		metric.WithDescription("Number of fingerprinted messages by site and dedup result"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dedup messages counter: %w", err)
 	}
 
-	return tokenizer.NewPipelineBuilder().AddStages(stgs...).Build(), registry
+	stageTokens, err := meter.Int64Counter(
+		"processor.tokenizer.stage.tokens",
+		metric.WithDescription("Number of tokens passed to, filtered and failed by the tokenizer stage"),
+		metric.WithUnit("{token}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stage tokens counter: %w", err)
+	}
+
+	stageDuration, err := meter.Float64Histogram(
+		"processor.tokenizer.stage.duration",
+		metric.WithDescription("Time spent by the tokenizer stage on a message"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stage duration histogram: %w", err)
+	}
+
+	return &Service{
+		repo:          repo,
+		llm:           llm,
+		mailer:        mailer,
+		scheduler:     scheduler,
+		pipeline:      pipeline,
+		dedupMessages: dedupMessages,
+		stageTokens:   stageTokens,
+		stageDuration: stageDuration,
+		cfg:           cfg,
+	}, nil
+}
+
+// metricsRegistry is a type alias for a map of metric names to Metric instances
+type metricsRegistry map[string]metrics.Metric
+
+// getTokenizer builds a new tokenizer pipeline with its own metrics registry
+func (s *Service) getTokenizer() (*tokenizer.Pipeline, metricsRegistry) {
+	return s.pipeline.Build()
 }
@@ -3,44 +3,90 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/keenywheels/backend/internal/pkg/client/llm"
 	tokenizerbase "github.com/keenywheels/backend/internal/pkg/tokenizer"
 	"github.com/keenywheels/backend/internal/processor/models"
+	"github.com/keenywheels/backend/internal/processor/repository"
 	"github.com/keenywheels/backend/pkg/ctxutils"
 )
 
 // TokenizeMessage processes and tokenizes the given message
 func (s *Service) TokenizeMessage(ctx context.Context, message string) error {
-	op := "Service.TokenizeMessage"
+	var (
+		op  = "Service.TokenizeMessage"
+		log = ctxutils.GetLogger(ctx)
+	)
 
 	var scraperEvent models.ScraperEvent
 	if err := json.Unmarshal([]byte(message), &scraperEvent); err != nil {
 		return fmt.Errorf("[%s] failed to unmarshal message: %w", op, err)
 	}
 
+	dateParsed, err := time.Parse(models.ScrapeDataFormat, scraperEvent.Date)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to parse scrape date %s: %w", op, scraperEvent.Date, err)
+	}
+
+	event := models.IngestedEvent{
+		Fingerprint: scraperEvent.Fingerprint(),
+		SiteName:    scraperEvent.SiteName,
+		Date:        dateParsed,
+	}
+
+	// skip redelivered and replayed events before expensive llm calls
+	ingested, err := s.repo.IsIngested(ctx, event.Fingerprint)
+	if err != nil {
+		return fmt.Errorf("[%s] failed to check event: %w", op, err)
+	}
+
+	if ingested {
+		log.Infof("[%s] event %s from %s was already ingested -> skip it", op, event.Fingerprint, event.SiteName)
+		return nil
+	}
+
 	// create tokenizer pipeline
-	tokenizer, registry := getTokenizer()
+	tokenizer, registry := s.getTokenizer()
 
 	// tokenize msg
-	tokens := tokenizer.Run(tokenizerbase.GetTokens(
-		scraperEvent.Msg,
-		tokenizerbase.NewTokenConfig(
-			tokenizerbase.DefaultTokenSource,
-			tokenizerbase.DefaultContextWindow,
-		),
-	))
-
-	tokensModel, err := s.parseTokens(ctx, &scraperEvent, tokens, registry)
+	tokens, err := tokenizer.Run(s.pipeline.Tokens(scraperEvent.Msg))
+
+	// stats are recorded for aborted runs too, so the failed stage can be found
+	s.recordPipelineStats(ctx, event.SiteName, tokenizer.Stats())
+
 	if err != nil {
-		return fmt.Errorf("[%s] failed to parse tokens: %w", op, err)
+		return fmt.Errorf("[%s] failed to tokenize message: %w", op, err)
+	}
+
+	// look up near-duplicates before expensive llm calls
+	if err := s.detectDuplicate(ctx, &event, tokens); err != nil {
+		return fmt.Errorf("[%s] failed to detect near-duplicate: %w", op, err)
+	}
+
+	// skipped near-duplicate is saved without tokens, so it is counted in stats and is not processed again
+	var tokensModel []models.TokenData
+
+	if !event.Duplicate || s.cfg.Dedup.Policy != DedupPolicySkip {
+		tokensModel, err = s.parseTokens(ctx, &scraperEvent, dateParsed, tokens, registry)
+		if err != nil {
+			return fmt.Errorf("[%s] failed to parse tokens: %w", op, err)
+		}
+	}
+
+	if event.Duplicate && s.cfg.Dedup.Policy == DedupPolicyDownweight {
+		s.downweightInterest(tokensModel)
 	}
 
-	// try to insert tokens
-	if err := s.repo.InsertTokens(ctx, tokensModel); err != nil {
+	// try to insert tokens, event could be ingested concurrently by another consumer
+	if err := s.repo.InsertTokens(ctx, event, tokensModel); err != nil {
+		if errors.Is(err, repository.ErrAlreadyIngested) {
+			log.Infof("[%s] event %s from %s was already ingested -> skip it", op, event.Fingerprint, event.SiteName)
+			return nil
+		}
+
 		return fmt.Errorf("[%s] failed to insert tokens batch: %w", op, err)
 	}
 
@@ -51,24 +97,20 @@ func (s *Service) TokenizeMessage(ctx context.Context, message string) error {
 func (s *Service) parseTokens(
 	ctx context.Context,
 	msg *models.ScraperEvent,
+	dateParsed time.Time,
 	tokens []tokenizerbase.Token,
 	registry metricsRegistry,
 ) ([]models.TokenData, error) {
 	var (
 		log      = ctxutils.GetLogger(ctx)
 		site     = msg.SiteName
-		date     = msg.Date
 		category = msg.Category
 		result   = make([]models.TokenData, 0, len(tokens))
 	)
 
-	dateParsed, err := time.Parse(models.ScrapeDataFormat, date)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse scrape date %s: %w", date, err)
-	}
-
 	uniqRes := make(map[string]int64)
 	tokensContext := make(map[string]*strings.Builder)
+	surfaceForms := make(map[string]map[string]int64)
 
 	for _, t := range tokens {
 		// skip filtered tokens
@@ -93,35 +135,75 @@ func (s *Service) parseTokens(
 			}
 
 			uniqRes[t.Target] = interestInt
+			surfaceForms[t.Target] = make(map[string]int64)
+		}
+
+		// count original forms, so token can be displayed in readable form
+		if form := t.SurfaceForm(); form != "" {
+			surfaceForms[t.Target][form]++
 		}
 
 		// append tokens context
-		for _, ctxToken := range t.Context {
+		for _, ctxToken := range t.ContextTokens() {
 			tokensContext[t.Target].WriteString(ctxToken.Target + " ")
 		}
-		tokensContext[t.Target].WriteString("; ")
+		tokensContext[t.Target].WriteString(contextSeparator + " ")
 	}
 
 	// make final result
+	sentiments, err := s.analyzeSentiments(ctx, tokensContext)
+	if err != nil {
+		return nil, err
+	}
+
 	for tokenName, interest := range uniqRes {
-		// analyze sentiment
-		resp, err := s.llm.SentimentAnalysis(ctx, &llm.SentimentAnalysisRequest{
-			Context: tokensContext[tokenName].String(),
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to analyze sentiment for token %s: %w", tokenName, err)
+		sentiment, ok := sentiments[tokenName]
+		if !ok {
+			continue // sentiment analysis failed and token is skipped
+		}
+
+		token := models.TokenData{
+			TokenName:           tokenName,
+			Interest:            interest,
+			Sentiment:           sentiment.value,
+			SentimentScore:      sentiment.score,
+			SentimentConfidence: sentiment.confidence,
+			PositiveMentions:    sentiment.mentions.Positive,
+			NeutralMentions:     sentiment.mentions.Neutral,
+			NegativeMentions:    sentiment.mentions.Negative,
+			SurfaceForms:        surfaceForms[tokenName],
+			CoOccurrences:       coOccurrences(registry, tokenName),
+			SiteName:            site,
+			Category:            category,
+			Date:                dateParsed,
+		}
+
+		// llm is unavailable -> save context, so sentiment will be backfilled later
+		if sentiment.pending {
+			token.SentimentPending = true
+			token.Context = tokensContext[tokenName].String()
 		}
 
 		// append to result
-		result = append(result, models.TokenData{
-			TokenName: tokenName,
-			Interest:  interest,
-			Sentiment: resp.Sentiment,
-			SiteName:  site,
-			Category:  category,
-			Date:      dateParsed,
-		})
+		result = append(result, token)
 	}
 
 	return result, nil
 }
+
+// coOccurrences returns tokens co-occurring with the token, nil if co-occurrence metric is not collected
+func coOccurrences(registry metricsRegistry, token string) map[string]int64 {
+	metric, ok := registry[coOccurrenceMetricKey]
+	if !ok {
+		return nil
+	}
+
+	val, ok := metric.Get(token)
+	if !ok {
+		return nil
+	}
+
+	related, _ := val.(map[string]int64)
+
+	return related
+}
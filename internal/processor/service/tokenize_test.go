@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keenywheels/backend/internal/pkg/tokenizer/stages"
+	"github.com/keenywheels/backend/internal/processor/models"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+	"github.com/keenywheels/backend/pkg/logger/zap"
+)
+
+func TestParseTokensSingleRowPerToken(t *testing.T) {
+	pipeline, err := stages.NewPipelineFactory(stages.DefaultPipelineConfig())
+	if err != nil {
+		t.Fatalf("failed to create pipeline factory: %v", err)
+	}
+
+	s := &Service{llm: &stubLLM{}, pipeline: pipeline}
+
+	msg := &models.ScraperEvent{
+		SiteName: "site",
+		Category: "tech",
+		Msg:      "Телефон быстро сел. Новый телефон лучше, телефоны этой марки хвалят",
+	}
+
+	tokenizer, registry := s.getTokenizer()
+
+	tokens, err := tokenizer.Run(pipeline.Tokens(msg.Msg))
+	if err != nil {
+		t.Fatalf("failed to run pipeline: %v", err)
+	}
+
+	ctx := ctxutils.SetLogger(context.Background(), zap.New(zap.LogPath(filepath.Join(t.TempDir(), "app.log"))))
+
+	result, err := s.parseTokens(ctx, msg, time.Now(), tokens, registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// doc frequency is the number of token rows, so the message is counted once for the repeated token
+	var rows []models.TokenData
+
+	for _, token := range result {
+		if token.TokenName == "телефон" {
+			rows = append(rows, token)
+		}
+	}
+
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row of the repeated token, got %d", len(rows))
+	}
+
+	if rows[0].Interest != 3 {
+		t.Fatalf("expected interest of 3, got %d", rows[0].Interest)
+	}
+}
@@ -99,3 +99,11 @@ func (r *Router) SearchTokenInfo(
 ) (gen.SearchTokenInfoRes, error) {
 	return r.searchController.SearchTokenInfo(ctx, req)
 }
+
+// GetRelatedTokens implements GetRelatedTokens for gen.Handler
+func (r *Router) GetRelatedTokens(
+	ctx context.Context,
+	req *gen.GetRelatedTokensRequest,
+) (gen.GetRelatedTokensRes, error) {
+	return r.searchController.GetRelatedTokens(ctx, req)
+}
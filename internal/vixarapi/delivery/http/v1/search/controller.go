@@ -9,6 +9,7 @@ import (
 // IService provides search-related service logic
 type IService interface {
 	SearchTokenInfo(context.Context, *service.SearchTokenInfoParams) ([]service.TokenInfo, error)
+	GetRelatedTokens(context.Context, *service.GetRelatedTokensParams) ([]service.RelatedToken, error)
 }
 
 // Controller contains handlers for endpoints
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gen "github.com/keenywheels/backend/internal/api/v1"
+	commonService "github.com/keenywheels/backend/internal/vixarapi/service"
+	service "github.com/keenywheels/backend/internal/vixarapi/service/search"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+	"github.com/keenywheels/backend/pkg/httputils"
+)
+
+// default values of related tokens request
+const (
+	defaultRelatedLimit       = 20
+	defaultRelatedMinMentions = 2
+)
+
+// GetRelatedTokens returns tokens which are mentioned together with the specified token.
+func (c *Controller) GetRelatedTokens(
+	ctx context.Context,
+	req *gen.GetRelatedTokensRequest,
+) (gen.GetRelatedTokensRes, error) {
+	var (
+		op  = "Controller.GetRelatedTokens"
+		log = ctxutils.GetLogger(ctx)
+	)
+
+	end := time.Now().UTC()
+	if req.End.Set {
+		end = req.End.Value.UTC()
+	}
+
+	var category *string
+	if req.Category.Set {
+		category = &req.Category.Value
+	}
+
+	limit := uint64(defaultRelatedLimit)
+	if req.Limit.Set {
+		limit = uint64(req.Limit.Value)
+	}
+
+	minMentions := int64(defaultRelatedMinMentions)
+	if req.MinMentions.Set {
+		minMentions = req.MinMentions.Value
+	}
+
+	related, err := c.svc.GetRelatedTokens(ctx, &service.GetRelatedTokensParams{
+		Token:       req.Token,
+		Category:    category,
+		Start:       req.Start.UTC(),
+		End:         end,
+		MinMentions: minMentions,
+		Limit:       limit,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, commonService.ErrNotFound):
+			return &gen.GetRelatedTokensNotFound{
+				Error: httputils.ErrorNotFound,
+			}, nil
+		}
+
+		log.Errorf("[%s] failed to get related tokens: %v", op, err)
+
+		return &gen.GetRelatedTokensInternalServerError{
+			Error: httputils.ErrorInternalError,
+		}, nil
+	}
+
+	resp := gen.GetRelatedTokensOKApplicationJSON(convertToRelatedTokensResp(related))
+
+	return &resp, nil
+}
+
+// convertToRelatedTokensResp converts service layer structs to api response structs
+func convertToRelatedTokensResp(tokens []service.RelatedToken) []gen.RelatedToken {
+	resp := make([]gen.RelatedToken, 0, len(tokens))
+
+	for _, t := range tokens {
+		resp = append(resp, gen.RelatedToken{
+			Token:       t.TokenName,
+			DisplayName: t.DisplayName,
+			Mentions:    t.Mentions,
+			Lift:        t.Lift,
+			Pmi:         t.PMI,
+		})
+	}
+
+	return resp
+}
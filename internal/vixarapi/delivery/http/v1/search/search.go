@@ -65,21 +65,38 @@ func convertToSearchTokenInfoResp(tokens []service.TokenInfo) []gen.TokenInfo {
 	for _, t := range tokens {
 		records := make([]gen.TokenRecord, 0, len(t.Records))
 		for _, r := range t.Records {
+			features := gen.TokenRecordFeatures{
+				Interest:               r.Interest,
+				InterestNormalized:     r.NormalizedInterest,
+				InterestCategory:       r.CategoryInterest,
+				DocFrequency:           r.DocFrequency,
+				DocFrequencyNormalized: r.NormalizedDocFreq,
+				DocFrequencyCategory:   r.CategoryDocFreq,
+				Sentiment:              r.Sentiment,
+				SentimentScore:         r.SentimentScore,
+				SentimentDistribution: gen.SentimentDistribution{
+					Positive: r.Distribution.Positive,
+					Neutral:  r.Distribution.Neutral,
+					Negative: r.Distribution.Negative,
+				},
+			}
+
+			// confidence is unknown if provider does not return it
+			if r.SentimentConfidence != nil {
+				features.SentimentConfidence = gen.NewOptFloat64(*r.SentimentConfidence)
+			}
+
 			records = append(records, gen.TokenRecord{
 				Timestamp: r.ScrapeDate,
-				Features: gen.TokenRecordFeatures{
-					Interest:           r.Interest,
-					InterestNormalized: r.NormalizedInterest,
-					InterestCategory:   r.CategoryInterest,
-					Sentiment:          r.Sentiment,
-				},
+				Features:  features,
 			})
 		}
 
 		resp = append(resp, gen.TokenInfo{
-			Token:    t.TokenName,
-			Category: t.Category,
-			Records:  records,
+			Token:       t.TokenName,
+			DisplayName: t.DisplayName,
+			Category:    t.Category,
+			Records:     records,
 		})
 	}
 
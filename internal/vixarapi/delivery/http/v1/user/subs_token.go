@@ -19,6 +19,9 @@ const (
 	methodDenormalized   = "denormalized"
 	methodGlobalMedian   = "global_median"
 	methodCategoryMedian = "category_median"
+
+	signalInterest     = "interest"
+	signalDocFrequency = "doc_frequency"
 )
 
 // SubscribeUserToToken subscribe the user to tokens update, so they can receive notifications
@@ -66,12 +69,23 @@ func (c *Controller) SubscribeUserToToken(
 		}, nil
 	}
 
+	// parse signal
+	signal, err := parseSignal(req.Signal)
+	if err != nil {
+		log.Errorf("[%s] invalid signal: %v", op, err)
+
+		return &gen.SubscribeUserToTokenBadRequest{
+			Error: httputils.ErrorBadRequest,
+		}, nil
+	}
+
 	// subscribe user to token
 	id, err := c.svc.SubscribeToToken(ctx, &service.SubscribeToTokenParams{
 		UserID:    userInfo.ID,
 		Token:     req.Token,
 		Category:  req.Category,
 		Method:    method,
+		Signal:    signal,
 		Threshold: req.Threshold,
 	})
 	if err != nil {
@@ -192,11 +206,25 @@ func (c *Controller) UpdateUserTokenSub(
 		}, nil
 	}
 
+	// parse signal, current signal is kept if not set
+	var signal string
+	if req.Signal.Set {
+		var err error
+		if signal, err = parseSignal(req.Signal); err != nil {
+			log.Errorf("[%s] invalid signal: %v", op, err)
+
+			return &gen.UpdateUserTokenSubBadRequest{
+				Error: httputils.ErrorBadRequest,
+			}, nil
+		}
+	}
+
 	// update token info
 	res, err := c.svc.UpdateTokenSubscription(ctx, &service.UpdateTokenSubParams{
 		ID:        req.ID,
 		Threshold: req.Threshold,
 		Method:    req.Method,
+		Signal:    signal,
 	})
 	if err != nil {
 		switch {
@@ -238,14 +266,35 @@ func parseMethod(reqMethod gen.OptString) (string, error) {
 	return method, nil
 }
 
+// parseSignal validate signal and set default value if wasn't set
+func parseSignal(reqSignal gen.OptString) (string, error) {
+	// return interest signal if not set
+	if !reqSignal.Set {
+		return signalInterest, nil
+	}
+
+	var (
+		signal       = strings.ToLower(reqSignal.Value)
+		validSignals = []string{signalInterest, signalDocFrequency}
+	)
+
+	if !slices.Contains(validSignals, signal) {
+		return "", fmt.Errorf("got unexpected signal: %s", signal)
+	}
+
+	return signal, nil
+}
+
 func convertTokenSubs(subs []*service.TokenSubInfo) []gen.UserTokenSub {
 	resp := make([]gen.UserTokenSub, 0, len(subs))
 	for _, s := range subs {
 		resp = append(resp, gen.UserTokenSub{
 			ID:               s.ID,
 			Token:            s.Token,
+			DisplayName:      s.DisplayName,
 			Category:         s.Category,
 			Method:           s.Method,
+			Signal:           s.Signal,
 			Threshold:        s.Threshold,
 			CurrentInterest:  s.CurrentInterest,
 			PreviousInterest: s.PreviousInterest,
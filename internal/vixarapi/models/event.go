@@ -10,6 +10,7 @@ type Notification struct {
 	Email            string    `json:"email"`
 	Username         string    `json:"username"`
 	Token            string    `json:"token"`
+	DisplayName      string    `json:"display_name,omitempty"`
 	Category         string    `json:"category"`
 	Threshold        float64   `json:"threshold"`
 	PreviousInterest float64   `json:"previous_interest"`
@@ -11,15 +11,28 @@ type Token struct {
 	Category       string
 	GlobalMedian   int64
 	CategoryMedian int64
+
+	// DocFrequency is number of distinct messages mentioning the token, medians are calculated over it
+	DocFrequency      int64
+	GlobalDocMedian   int64
+	CategoryDocMedian int64
 }
 
 // TokenRecord represent a single record of token data
 type TokenRecord struct {
-	ScrapeDate       time.Time
-	Interest         int64
-	GlobalInterest   float64
-	CategoryInterest float64
-	Sentiment        int16
+	ScrapeDate           time.Time
+	Interest             int64
+	GlobalInterest       float64
+	CategoryInterest     float64
+	DocFrequency         int64
+	GlobalDocFrequency   float64
+	CategoryDocFrequency float64
+	Sentiment            int16
+	SentimentScore       float64
+	SentimentConfidence  *float64
+	PositiveMentions     int64
+	NeutralMentions      int64
+	NegativeMentions     int64
 }
 
 // TokenInfo represent information about a token in database
@@ -28,3 +41,11 @@ type TokenInfo struct {
 	Category  string
 	Records   []TokenRecord
 }
+
+// RelatedToken represent a token mentioned together with another token in database,
+// Lift is ratio of observed co-occurrences to expected ones if tokens were independent
+type RelatedToken struct {
+	TokenName string
+	Mentions  int64
+	Lift      float64
+}
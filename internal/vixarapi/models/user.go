@@ -34,6 +34,7 @@ type UserTokenSub struct {
 	PreviousInterest float64
 	Threshold        float64
 	Method           string
+	Signal           string
 	ScanDate         time.Time
 	CreatedAt        time.Time
 }
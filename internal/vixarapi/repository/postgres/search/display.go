@@ -0,0 +1,82 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	commonRepo "github.com/keenywheels/backend/internal/vixarapi/repository/postgres"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+)
+
+// GetDisplayNamesParams parameters for getting display names of tokens, zero dates mean no bound
+type GetDisplayNamesParams struct {
+	Tokens []string
+	Start  time.Time
+	End    time.Time
+}
+
+// GetDisplayNames returns the most frequent surface form of every token within the dates,
+// tokens without known surface forms are not returned
+func (r *Repository) GetDisplayNames(ctx context.Context, params *GetDisplayNamesParams) (map[string]string, error) {
+	op := "Repository.GetDisplayNames"
+
+	names := make(map[string]string, len(params.Tokens))
+	if len(params.Tokens) == 0 {
+		return names, nil
+	}
+
+	filter := sq.And{
+		sq.Eq{r.tbls.forms.Fields.TokenName: params.Tokens},
+	}
+
+	if !params.Start.IsZero() {
+		filter = append(filter, sq.GtOrEq{r.tbls.forms.Fields.ScrapeDate: params.Start})
+	}
+
+	if !params.End.IsZero() {
+		filter = append(filter, sq.LtOrEq{r.tbls.forms.Fields.ScrapeDate: params.End})
+	}
+
+	query, args, err := r.db.Builder.
+		Select(
+			fmt.Sprintf("DISTINCT ON (%[1]s) %[1]s", r.tbls.forms.Fields.TokenName),
+			r.tbls.forms.Fields.SurfaceForm,
+		).
+		From(r.tbls.forms.Name).
+		Where(filter).
+		GroupBy(r.tbls.forms.Fields.TokenName, r.tbls.forms.Fields.SurfaceForm).
+		OrderBy(
+			r.tbls.forms.Fields.TokenName,
+			fmt.Sprintf("SUM(%s) DESC", r.tbls.forms.Fields.Mentions),
+			r.tbls.forms.Fields.SurfaceForm,
+		).
+		ToSql()
+	if err != nil {
+		return nil, commonRepo.ParsePostgresError(op, err)
+	}
+
+	ctxutils.GetLogger(ctx).Debugf("[%s] get display names query: %s, args: %v", op, query, args)
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, commonRepo.ParsePostgresError(op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var token, name string
+		if err := rows.Scan(&token, &name); err != nil {
+			return nil, commonRepo.ParsePostgresError(op, err)
+		}
+
+		names[token] = name
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, commonRepo.ParsePostgresError(op, err)
+	}
+
+	return names, nil
+}
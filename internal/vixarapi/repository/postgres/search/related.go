@@ -0,0 +1,122 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/keenywheels/backend/internal/vixarapi/models"
+	commonRepo "github.com/keenywheels/backend/internal/vixarapi/repository/postgres"
+	"github.com/keenywheels/backend/pkg/ctxutils"
+)
+
+// GetRelatedTokensParams parameters for getting related tokens
+type GetRelatedTokensParams struct {
+	Token       string
+	Category    *string
+	Start       time.Time
+	End         time.Time
+	MinMentions int64
+	Limit       uint64
+}
+
+// GetRelatedTokens returns tokens mentioned together with the token at least MinMentions times
+// sorted by lift: mentions * total / (token mentions * related token mentions), where mentions of
+// a token are its co-occurrences with any token and total is the number of all co-occurrences
+func (r *Repository) GetRelatedTokens(ctx context.Context, params *GetRelatedTokensParams) ([]models.RelatedToken, error) {
+	op := "Repository.GetRelatedTokens"
+
+	f := r.tbls.pairs.Fields
+
+	// all counters are taken within the dates and category
+	filter := sq.And{
+		sq.GtOrEq{f.ScrapeDate: params.Start},
+		sq.LtOrEq{f.ScrapeDate: params.End},
+	}
+
+	if params.Category != nil {
+		filter = append(filter, sq.Eq{f.Category: *params.Category})
+	}
+
+	pairs := r.db.Builder.
+		Select(
+			fmt.Sprintf("%s AS token_name", f.RelatedToken),
+			fmt.Sprintf("SUM(%s)::BIGINT AS mentions", f.Mentions),
+		).
+		From(r.tbls.pairs.Name).
+		Where(sq.And{sq.Eq{f.TokenName: params.Token}, filter}).
+		GroupBy(f.RelatedToken).
+		Having(fmt.Sprintf("SUM(%s) >= ?", f.Mentions), params.MinMentions)
+
+	totals := r.db.Builder.
+		Select(
+			f.TokenName,
+			fmt.Sprintf("SUM(%s)::BIGINT AS mentions", f.Mentions),
+		).
+		From(r.tbls.pairs.Name).
+		Where(sq.And{
+			sq.Or{
+				sq.Eq{f.TokenName: params.Token},
+				sq.Expr(fmt.Sprintf("%s IN (SELECT token_name FROM pairs)", f.TokenName)),
+			},
+			filter,
+		}).
+		GroupBy(f.TokenName)
+
+	total := r.db.Builder.
+		Select(fmt.Sprintf("SUM(%s)::BIGINT AS mentions", f.Mentions)).
+		From(r.tbls.pairs.Name).
+		Where(filter)
+
+	query, args, err := r.db.Builder.
+		Select(
+			"p.token_name",
+			"p.mentions",
+			"(1.0 * p.mentions * t.mentions / q.mentions / r.mentions)::DOUBLE PRECISION AS lift",
+		).
+		Prefix("WITH").
+		Prefix("pairs AS (").PrefixExpr(pairs).Prefix("),").
+		Prefix("totals AS (").PrefixExpr(totals).Prefix("),").
+		Prefix("total AS (").PrefixExpr(total).Prefix(")").
+		From("pairs p").
+		Join("totals r ON r.token_name = p.token_name").
+		Join("totals q ON q.token_name = ?", params.Token).
+		CrossJoin("total t").
+		OrderBy("lift DESC", "p.mentions DESC", "p.token_name").
+		Limit(params.Limit).
+		ToSql()
+	if err != nil {
+		return nil, commonRepo.ParsePostgresError(op, err)
+	}
+
+	ctxutils.GetLogger(ctx).Debugf("[%s] get related tokens query: %s, args: %v", op, query, args)
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, commonRepo.ParsePostgresError(op, err)
+	}
+	defer rows.Close()
+
+	var res []models.RelatedToken
+
+	for rows.Next() {
+		var token models.RelatedToken
+
+		if err := rows.Scan(&token.TokenName, &token.Mentions, &token.Lift); err != nil {
+			return nil, commonRepo.ParsePostgresError(op, err)
+		}
+
+		res = append(res, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, commonRepo.ParsePostgresError(op, err)
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("[%s] failed to find related tokens: %w", op, commonRepo.ErrNotFound)
+	}
+
+	return res, nil
+}
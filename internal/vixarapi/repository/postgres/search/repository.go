@@ -13,6 +13,8 @@ const (
 type Tables struct {
 	search commonRepo.SearchTokenTable
 	uts    commonRepo.UserTokenSubTable
+	forms  commonRepo.SurfaceFormTable
+	pairs  commonRepo.CoOccurrenceTable
 }
 
 // Repository provides interest-related data access logic
@@ -27,6 +29,8 @@ func New(db *postgres.Postgres) *Repository {
 		tbls: Tables{
 			search: commonRepo.NewSearchTokenTable(),
 			uts:    commonRepo.NewUserTokenSubTable(),
+			forms:  commonRepo.NewSurfaceFormTable(),
+			pairs:  commonRepo.NewCoOccurrenceTable(),
 		},
 		db: db,
 	}
@@ -39,10 +39,17 @@ func (r *Repository) UpdateUserTokenSubs(ctx context.Context, intervalType strin
 				new_token_interest AS (SELECT uts.id  AS user_token_sub_id,
 											  uts.method,
 											  ts.scrape_date,
-											  CASE uts.method
-												  WHEN 'global_median' THEN ts.interest / ts.global_median
-												  WHEN 'category_median' THEN ts.interest / ts.category_median
-												  ELSE ts.interest
+											  CASE uts.signal
+												  WHEN 'doc_frequency' THEN CASE uts.method
+																				WHEN 'global_median' THEN ts.doc_frequency / GREATEST(ts.global_doc_median, 1)
+																				WHEN 'category_median' THEN ts.doc_frequency / GREATEST(ts.category_doc_median, 1)
+																				ELSE ts.doc_frequency
+																				END
+												  ELSE CASE uts.method
+														   WHEN 'global_median' THEN ts.interest / GREATEST(ts.global_median, 1)
+														   WHEN 'category_median' THEN ts.interest / GREATEST(ts.category_median, 1)
+														   ELSE ts.interest
+														   END
 												  END AS interest
 									   FROM user_token_sub uts
 												JOIN mv_token_search ts
@@ -55,9 +55,17 @@ func (r *Repository) SearchTokenInfo(
 			r.tbls.search.Fields.Category,
 			r.tbls.search.Fields.ScrapeDate,
 			r.tbls.search.Fields.Interest,
-			fmt.Sprintf("1.0 * %s / %s", r.tbls.search.Fields.Interest, r.tbls.search.Fields.GlobalMedian),
-			fmt.Sprintf("1.0 * %s / %s", r.tbls.search.Fields.Interest, r.tbls.search.Fields.CategoryMedian),
+			fmt.Sprintf("1.0 * %s / GREATEST(%s, 1)", r.tbls.search.Fields.Interest, r.tbls.search.Fields.GlobalMedian),
+			fmt.Sprintf("1.0 * %s / GREATEST(%s, 1)", r.tbls.search.Fields.Interest, r.tbls.search.Fields.CategoryMedian),
+			r.tbls.search.Fields.DocFrequency,
+			fmt.Sprintf("1.0 * %s / GREATEST(%s, 1)", r.tbls.search.Fields.DocFrequency, r.tbls.search.Fields.GlobalDocMedian),
+			fmt.Sprintf("1.0 * %s / GREATEST(%s, 1)", r.tbls.search.Fields.DocFrequency, r.tbls.search.Fields.CategoryDocMedian),
 			r.tbls.search.Fields.Sentiment,
+			r.tbls.search.Fields.SentimentScore,
+			r.tbls.search.Fields.SentimentConfidence,
+			r.tbls.search.Fields.PositiveMentions,
+			r.tbls.search.Fields.NeutralMentions,
+			r.tbls.search.Fields.NegativeMentions,
 		).
 		From(r.tbls.search.Name).
 		Where(sq.And(filter)).
@@ -97,7 +105,15 @@ func (r *Repository) SearchTokenInfo(
 			&record.Interest,
 			&record.GlobalInterest,
 			&record.CategoryInterest,
+			&record.DocFrequency,
+			&record.GlobalDocFrequency,
+			&record.CategoryDocFrequency,
 			&record.Sentiment,
+			&record.SentimentScore,
+			&record.SentimentConfidence,
+			&record.PositiveMentions,
+			&record.NeutralMentions,
+			&record.NegativeMentions,
 		); err != nil {
 			return nil, commonRepo.ParsePostgresError(op, err)
 		}
@@ -163,6 +179,9 @@ func (r *Repository) GetLatestToken(ctx context.Context, params *GetTokenParams)
 			r.tbls.search.Fields.Category,
 			r.tbls.search.Fields.GlobalMedian,
 			r.tbls.search.Fields.CategoryMedian,
+			r.tbls.search.Fields.DocFrequency,
+			r.tbls.search.Fields.GlobalDocMedian,
+			r.tbls.search.Fields.CategoryDocMedian,
 		).
 		From(r.tbls.search.Name).
 		Where(sq.And{
@@ -186,6 +205,9 @@ func (r *Repository) GetLatestToken(ctx context.Context, params *GetTokenParams)
 		&token.Category,
 		&token.GlobalMedian,
 		&token.CategoryMedian,
+		&token.DocFrequency,
+		&token.GlobalDocMedian,
+		&token.CategoryDocMedian,
 	); err != nil {
 		return nil, commonRepo.ParsePostgresError(op, err)
 	}
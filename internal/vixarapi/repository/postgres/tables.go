@@ -2,13 +2,21 @@ package postgres
 
 // SearchTokenFields represents the fields of the search token table
 type SearchTokenFields struct {
-	TokenName      string
-	ScrapeDate     string
-	Interest       string
-	Sentiment      string
-	Category       string
-	GlobalMedian   string
-	CategoryMedian string
+	TokenName           string
+	ScrapeDate          string
+	Interest            string
+	DocFrequency        string
+	Sentiment           string
+	SentimentScore      string
+	SentimentConfidence string
+	PositiveMentions    string
+	NeutralMentions     string
+	NegativeMentions    string
+	Category            string
+	GlobalMedian        string
+	CategoryMedian      string
+	GlobalDocMedian     string
+	CategoryDocMedian   string
 }
 
 // SearchTokenTable represents the structure of the search token table
@@ -22,13 +30,77 @@ func NewSearchTokenTable() SearchTokenTable {
 	return SearchTokenTable{
 		Name: "mv_token_search",
 		Fields: SearchTokenFields{
-			TokenName:      "token_name",
-			ScrapeDate:     "scrape_date",
-			Interest:       "interest",
-			Sentiment:      "sentiment",
-			Category:       "category",
-			GlobalMedian:   "global_median",
-			CategoryMedian: "category_median",
+			TokenName:           "token_name",
+			ScrapeDate:          "scrape_date",
+			Interest:            "interest",
+			DocFrequency:        "doc_frequency",
+			Sentiment:           "sentiment",
+			SentimentScore:      "sentiment_score",
+			SentimentConfidence: "sentiment_confidence",
+			PositiveMentions:    "mentions_positive",
+			NeutralMentions:     "mentions_neutral",
+			NegativeMentions:    "mentions_negative",
+			Category:            "category",
+			GlobalMedian:        "global_median",
+			CategoryMedian:      "category_median",
+			GlobalDocMedian:     "global_doc_median",
+			CategoryDocMedian:   "category_doc_median",
+		},
+	}
+}
+
+// SurfaceFormFields represents the fields of the token surface forms table
+type SurfaceFormFields struct {
+	TokenName   string
+	ScrapeDate  string
+	SurfaceForm string
+	Mentions    string
+}
+
+// SurfaceFormTable represents the structure of the token surface forms table
+type SurfaceFormTable struct {
+	Name   string
+	Fields SurfaceFormFields
+}
+
+// NewSurfaceFormTable creates a new instance of SurfaceFormTable
+func NewSurfaceFormTable() SurfaceFormTable {
+	return SurfaceFormTable{
+		Name: "token_surface_forms",
+		Fields: SurfaceFormFields{
+			TokenName:   "token_name",
+			ScrapeDate:  "scrape_date",
+			SurfaceForm: "surface_form",
+			Mentions:    "mentions",
+		},
+	}
+}
+
+// CoOccurrenceFields represents the fields of the token co-occurrence table
+type CoOccurrenceFields struct {
+	TokenName    string
+	ScrapeDate   string
+	Category     string
+	RelatedToken string
+	Mentions     string
+}
+
+// CoOccurrenceTable represents the structure of the token co-occurrence table
+type CoOccurrenceTable struct {
+	Name   string
+	Fields CoOccurrenceFields
+}
+
+// NewCoOccurrenceTable creates a new instance of CoOccurrenceTable
+func NewCoOccurrenceTable() CoOccurrenceTable {
+	return CoOccurrenceTable{
+		Name: "token_cooccurrence",
+		Fields: CoOccurrenceFields{
+			TokenName:    "token_name",
+			ScrapeDate:   "scrape_date",
+			Category:     "category",
+			RelatedToken: "related_token",
+			Mentions:     "mentions",
 		},
 	}
 }
@@ -107,6 +179,7 @@ type UserTokenSubFields struct {
 	PreviousInterest string
 	Threshold        string
 	Method           string
+	Signal           string
 	ScanDate         string
 	CreatedAt        string
 }
@@ -124,6 +197,7 @@ func NewUserTokenSubTable() UserTokenSubTable {
 			PreviousInterest: "prv_interest",
 			Threshold:        "threshold",
 			Method:           "method",
+			Signal:           "signal",
 			ScanDate:         "scan_date",
 			CreatedAt:        "created_at",
 		},
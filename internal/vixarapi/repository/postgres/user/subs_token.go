@@ -18,6 +18,7 @@ type AddTokenSubParams struct {
 	Interest  int64
 	Threshold float64
 	Method    string
+	Signal    string
 	ScanDate  time.Time
 }
 
@@ -34,6 +35,7 @@ func (r *Repository) AddTokenSub(ctx context.Context, params *AddTokenSubParams)
 			r.tbls.userTokenSub.Fields.CurrentInterest,
 			r.tbls.userTokenSub.Fields.Threshold,
 			r.tbls.userTokenSub.Fields.Method,
+			r.tbls.userTokenSub.Fields.Signal,
 			r.tbls.userTokenSub.Fields.ScanDate,
 		).
 		Values(
@@ -43,6 +45,7 @@ func (r *Repository) AddTokenSub(ctx context.Context, params *AddTokenSubParams)
 			params.Interest,
 			params.Threshold,
 			params.Method,
+			params.Signal,
 			params.ScanDate,
 		).
 		Suffix(fmt.Sprintf("RETURNING %s", r.tbls.userTokenSub.Fields.ID)).
@@ -79,6 +82,7 @@ func (r *Repository) GetTokenSubs(
 			r.tbls.userTokenSub.Fields.PreviousInterest,
 			r.tbls.userTokenSub.Fields.Threshold,
 			r.tbls.userTokenSub.Fields.Method,
+			r.tbls.userTokenSub.Fields.Signal,
 			r.tbls.userTokenSub.Fields.ScanDate,
 			r.tbls.userTokenSub.Fields.CreatedAt,
 		).
@@ -112,6 +116,7 @@ func (r *Repository) GetTokenSubs(
 			&sub.PreviousInterest,
 			&sub.Threshold,
 			&sub.Method,
+			&sub.Signal,
 			&sub.ScanDate,
 			&sub.CreatedAt,
 		); err != nil {
@@ -156,11 +161,13 @@ func (r *Repository) DeleteTokenSub(ctx context.Context, id string) error {
 	return nil
 }
 
-// UpdateTokenSubParams represents parameters for updating a token subscription in database
+// UpdateTokenSubParams represents parameters for updating a token subscription in database,
+// current signal is kept if Signal is empty
 type UpdateTokenSubParams struct {
 	ID        string
 	Threshold float64
 	Method    string
+	Signal    string
 }
 
 type UpdateTokenSubResult struct {
@@ -187,22 +194,41 @@ func (r *Repository) UpdateTokenSub(ctx context.Context, params *UpdateTokenSubP
 												 ON uts.token = ts.token_name AND uts.category = ts.category
 								   WHERE uts.id = $1
 									 AND (ts.scrape_date = uts.scan_date - INTERVAL '1 days')),
-				new_data AS (SELECT $2::numeric            AS threshold,
-									$3::text               AS method,
-									(SELECT CASE $3
-												WHEN 'global_median' THEN interest / global_median
-												WHEN 'category_median' THEN interest / category_median
-												ELSE interest
+				new_signal AS (SELECT COALESCE(NULLIF($4::text, ''), signal) AS signal
+							   FROM user_token_sub
+							   WHERE id = $1),
+				new_data AS (SELECT $2::numeric                     AS threshold,
+									$3::text                        AS method,
+									(SELECT signal FROM new_signal) AS signal,
+									(SELECT CASE (SELECT signal FROM new_signal)
+												WHEN 'doc_frequency' THEN CASE $3
+																			  WHEN 'global_median' THEN doc_frequency / GREATEST(global_doc_median, 1)
+																			  WHEN 'category_median' THEN doc_frequency / GREATEST(category_doc_median, 1)
+																			  ELSE doc_frequency
+																			  END
+												ELSE CASE $3
+														 WHEN 'global_median' THEN interest / GREATEST(global_median, 1)
+														 WHEN 'category_median' THEN interest / GREATEST(category_median, 1)
+														 ELSE interest
+														 END
 												END
-									 FROM curr_token_info) AS curr_interest,
-									(SELECT CASE $3
-												WHEN 'global_median' THEN interest / global_median
-												WHEN 'category_median' THEN interest / category_median
-												ELSE interest
+									 FROM curr_token_info)          AS curr_interest,
+									(SELECT CASE (SELECT signal FROM new_signal)
+												WHEN 'doc_frequency' THEN CASE $3
+																			  WHEN 'global_median' THEN doc_frequency / GREATEST(global_doc_median, 1)
+																			  WHEN 'category_median' THEN doc_frequency / GREATEST(category_doc_median, 1)
+																			  ELSE doc_frequency
+																			  END
+												ELSE CASE $3
+														 WHEN 'global_median' THEN interest / GREATEST(global_median, 1)
+														 WHEN 'category_median' THEN interest / GREATEST(category_median, 1)
+														 ELSE interest
+														 END
 												END
-									 FROM prv_token_info)  AS prv_interest)
+									 FROM prv_token_info)           AS prv_interest)
 			UPDATE user_token_sub uts
 			SET method        = nd.method,
+				signal        = nd.signal,
 				threshold     = nd.threshold,
 				curr_interest = nd.curr_interest,
 				prv_interest  = nd.prv_interest
@@ -210,7 +236,7 @@ func (r *Repository) UpdateTokenSub(ctx context.Context, params *UpdateTokenSubP
 			WHERE uts.id = $1
 			RETURNING nd.curr_interest, nd.prv_interest;
 		`
-		args = []any{params.ID, params.Threshold, params.Method}
+		args = []any{params.ID, params.Threshold, params.Method, params.Signal}
 	)
 
 	// update user token sub
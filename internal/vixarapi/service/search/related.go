@@ -0,0 +1,78 @@
+package search
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/keenywheels/backend/internal/vixarapi/models"
+	repo "github.com/keenywheels/backend/internal/vixarapi/repository/postgres/search"
+	"github.com/keenywheels/backend/internal/vixarapi/service"
+)
+
+// RelatedToken token mentioned together with the requested token in service layer
+type RelatedToken struct {
+	TokenName   string
+	DisplayName string
+	Mentions    int64
+	Lift        float64
+	PMI         float64 // log2 of lift
+}
+
+// GetRelatedTokensParams parameters for getting related tokens
+type GetRelatedTokensParams struct {
+	Token       string
+	Category    *string
+	Start       time.Time
+	End         time.Time
+	MinMentions int64
+	Limit       uint64
+}
+
+// GetRelatedTokens retrieves tokens which are mentioned together with the specified token more often than
+// expected, sorted by lift
+func (s *Service) GetRelatedTokens(ctx context.Context, params *GetRelatedTokensParams) ([]RelatedToken, error) {
+	op := "Service.GetRelatedTokens"
+
+	related, err := s.r.GetRelatedTokens(ctx, &repo.GetRelatedTokensParams{
+		Token:       params.Token,
+		Category:    params.Category,
+		Start:       params.Start,
+		End:         params.End,
+		MinMentions: params.MinMentions,
+		Limit:       params.Limit,
+	})
+	if err != nil {
+		return nil, service.ParseRepositoryError(op, err)
+	}
+
+	tokens := make([]string, 0, len(related))
+	for _, t := range related {
+		tokens = append(tokens, t.TokenName)
+	}
+
+	names := s.getDisplayNames(ctx, &repo.GetDisplayNamesParams{
+		Tokens: tokens,
+		Start:  params.Start,
+		End:    params.End,
+	})
+
+	return convertToServiceRelatedTokens(related, names), nil
+}
+
+// convertToServiceRelatedTokens converts repository structs to service layer structs
+func convertToServiceRelatedTokens(tokens []models.RelatedToken, names map[string]string) []RelatedToken {
+	resp := make([]RelatedToken, 0, len(tokens))
+
+	for _, t := range tokens {
+		resp = append(resp, RelatedToken{
+			TokenName:   t.TokenName,
+			DisplayName: displayName(names, t.TokenName),
+			Mentions:    t.Mentions,
+			Lift:        t.Lift,
+			PMI:         math.Log2(t.Lift),
+		})
+	}
+
+	return resp
+}
@@ -15,6 +15,8 @@ type IRepository interface {
 	UpdateSearchTable(context.Context) error
 	UpdateUserTokenSubs(ctx context.Context, intervalType string, amount int) error
 	GetIncreasedTokenSubs(ctx context.Context, limit uint64, offset uint64) ([]*repo.IncreasedTokenSubInfo, error)
+	GetDisplayNames(ctx context.Context, params *repo.GetDisplayNamesParams) (map[string]string, error)
+	GetRelatedTokens(ctx context.Context, params *repo.GetRelatedTokensParams) ([]models.RelatedToken, error)
 }
 
 // IBroker provides interface to communicate with message broker
@@ -74,6 +74,13 @@ func (s *Service) putNotificationTasks(ctx context.Context) (uint64, error) {
 			break
 		}
 
+		tokens := make([]string, 0, len(subs))
+		for _, sub := range subs {
+			tokens = append(tokens, sub.Token)
+		}
+
+		names := s.getDisplayNames(ctx, &repo.GetDisplayNamesParams{Tokens: tokens})
+
 		// put task for every sub
 		for _, sub := range subs {
 			// TODO: надо доработать логику, чтобы была защита от повторных отправок уведомлений
@@ -85,6 +92,7 @@ func (s *Service) putNotificationTasks(ctx context.Context) (uint64, error) {
 				Username:         sub.Username,
 				Email:            sub.Email,
 				Token:            sub.Token,
+				DisplayName:      displayName(names, sub.Token),
 				Category:         sub.Category,
 				Threshold:        sub.Threshold,
 				PreviousInterest: sub.PreviousInterest,
@@ -7,6 +7,7 @@ import (
 	"github.com/keenywheels/backend/internal/vixarapi/models"
 	repo "github.com/keenywheels/backend/internal/vixarapi/repository/postgres/search"
 	"github.com/keenywheels/backend/internal/vixarapi/service"
+	"github.com/keenywheels/backend/pkg/ctxutils"
 )
 
 const (
@@ -15,18 +16,32 @@ const (
 
 // Record represent a single record of token data
 type Record struct {
-	ScrapeDate         string
-	Interest           int64
-	NormalizedInterest float64
-	CategoryInterest   float64
-	Sentiment          int16
+	ScrapeDate          string
+	Interest            int64
+	NormalizedInterest  float64
+	CategoryInterest    float64
+	DocFrequency        int64
+	NormalizedDocFreq   float64
+	CategoryDocFreq     float64
+	Sentiment           int16
+	SentimentScore      float64
+	SentimentConfidence *float64
+	Distribution        SentimentDistribution
+}
+
+// SentimentDistribution represents numbers of positive, neutral and negative mentions of the token
+type SentimentDistribution struct {
+	Positive int64
+	Neutral  int64
+	Negative int64
 }
 
 // TokenInfo token info in service layer
 type TokenInfo struct {
-	TokenName string // TODO: подумать над этим полем, мб попробовать привести к нормальной форме?
-	Category  string
-	Records   []Record
+	TokenName   string
+	DisplayName string // the most frequent original form of the token, token name if unknown
+	Category    string
+	Records     []Record
 }
 
 // SearchTokenInfoParams parameters for searching token info
@@ -53,29 +68,74 @@ func (s *Service) SearchTokenInfo(ctx context.Context, params *SearchTokenInfoPa
 		return nil, service.ParseRepositoryError(op, err)
 	}
 
-	return convertToServiceTokenInfo(tokensInfo), nil
+	tokens := make([]string, 0, len(tokensInfo))
+	for _, t := range tokensInfo {
+		tokens = append(tokens, t.TokenName)
+	}
+
+	names := s.getDisplayNames(ctx, &repo.GetDisplayNamesParams{
+		Tokens: tokens,
+		Start:  params.Start,
+		End:    params.End,
+	})
+
+	return convertToServiceTokenInfo(tokensInfo, names), nil
+}
+
+// getDisplayNames returns display names of the tokens, display names are not essential,
+// so error is only logged and tokens are displayed as is
+func (s *Service) getDisplayNames(ctx context.Context, params *repo.GetDisplayNamesParams) map[string]string {
+	op := "Service.getDisplayNames"
+
+	names, err := s.r.GetDisplayNames(ctx, params)
+	if err != nil {
+		ctxutils.GetLogger(ctx).Warnf("[%s] failed to get display names: %v", op, err)
+		return nil
+	}
+
+	return names
+}
+
+// displayName returns display name of the token or the token itself if display name is unknown
+func displayName(names map[string]string, token string) string {
+	if name, ok := names[token]; ok {
+		return name
+	}
+
+	return token
 }
 
 // convertToServiceTokenInfo converts repository structs to service layer structs
-func convertToServiceTokenInfo(tokens []models.TokenInfo) []TokenInfo {
+func convertToServiceTokenInfo(tokens []models.TokenInfo, names map[string]string) []TokenInfo {
 	resp := make([]TokenInfo, 0, len(tokens))
 
 	for _, t := range tokens {
 		records := make([]Record, 0, len(t.Records))
 		for _, r := range t.Records {
 			records = append(records, Record{
-				ScrapeDate:         r.ScrapeDate.Format(timedateLayout),
-				Interest:           r.Interest,
-				NormalizedInterest: r.GlobalInterest,
-				CategoryInterest:   r.CategoryInterest,
-				Sentiment:          r.Sentiment,
+				ScrapeDate:          r.ScrapeDate.Format(timedateLayout),
+				Interest:            r.Interest,
+				NormalizedInterest:  r.GlobalInterest,
+				CategoryInterest:    r.CategoryInterest,
+				DocFrequency:        r.DocFrequency,
+				NormalizedDocFreq:   r.GlobalDocFrequency,
+				CategoryDocFreq:     r.CategoryDocFrequency,
+				Sentiment:           r.Sentiment,
+				SentimentScore:      r.SentimentScore,
+				SentimentConfidence: r.SentimentConfidence,
+				Distribution: SentimentDistribution{
+					Positive: r.PositiveMentions,
+					Neutral:  r.NeutralMentions,
+					Negative: r.NegativeMentions,
+				},
 			})
 		}
 
 		resp = append(resp, TokenInfo{
-			TokenName: t.TokenName,
-			Category:  t.Category,
-			Records:   records,
+			TokenName:   t.TokenName,
+			DisplayName: displayName(names, t.TokenName),
+			Category:    t.Category,
+			Records:     records,
 		})
 	}
 
@@ -26,6 +26,7 @@ type IRepository interface {
 // ISearch provides interface to communicate with the search repository layer
 type ISearch interface {
 	GetLatestToken(ctx context.Context, params *search.GetTokenParams) (*models.Token, error)
+	GetDisplayNames(ctx context.Context, params *search.GetDisplayNamesParams) (map[string]string, error)
 }
 
 // ISession provides interface to communicate with the session repository layer
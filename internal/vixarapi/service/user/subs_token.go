@@ -10,12 +10,16 @@ import (
 	"github.com/keenywheels/backend/internal/vixarapi/repository/postgres/search"
 	"github.com/keenywheels/backend/internal/vixarapi/repository/postgres/user"
 	"github.com/keenywheels/backend/internal/vixarapi/service"
+	"github.com/keenywheels/backend/pkg/ctxutils"
 )
 
 const (
 	methodDenormalized   = "denormalized"
 	methodGlobalMedian   = "global_median"
 	methodCategoryMedian = "category_median"
+
+	signalInterest     = "interest"
+	signalDocFrequency = "doc_frequency"
 )
 
 // SubscribeToTokenParams represents parameters for subscribing to token updates
@@ -24,6 +28,7 @@ type SubscribeToTokenParams struct {
 	Token     string
 	Category  string
 	Method    string
+	Signal    string
 	Threshold float64
 }
 
@@ -39,9 +44,9 @@ func (s *Service) SubscribeToToken(ctx context.Context, params *SubscribeToToken
 		return "", service.ParseRepositoryError(op, err)
 	}
 
-	interest, err := parseInterest(token, params.Method)
+	interest, err := parseInterest(token, params.Method, params.Signal)
 	if err != nil {
-		return "", fmt.Errorf("[%s] got unexpected method: %w", op, err)
+		return "", fmt.Errorf("[%s] failed to parse interest: %w", op, err)
 	}
 
 	subID, err := s.repo.AddTokenSub(ctx, &user.AddTokenSubParams{
@@ -51,6 +56,7 @@ func (s *Service) SubscribeToToken(ctx context.Context, params *SubscribeToToken
 		Interest:  interest,
 		Threshold: params.Threshold,
 		Method:    params.Method,
+		Signal:    params.Signal,
 		ScanDate:  token.ScrapeDate,
 	})
 	if err != nil {
@@ -64,8 +70,10 @@ func (s *Service) SubscribeToToken(ctx context.Context, params *SubscribeToToken
 type TokenSubInfo struct {
 	ID               string
 	Token            string
+	DisplayName      string // the most frequent original form of the token, token itself if unknown
 	Category         string
 	Method           string
+	Signal           string
 	Threshold        float64
 	CurrentInterest  float64
 	PreviousInterest float64
@@ -81,7 +89,18 @@ func (s *Service) GetSubscribedTokens(ctx context.Context, userID string, limit,
 		return nil, service.ParseRepositoryError(op, err)
 	}
 
-	return convertTokenSubs(subs), nil
+	tokens := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		tokens = append(tokens, sub.Token)
+	}
+
+	// display names are not essential, so subs are returned even if they are failed to get
+	names, err := s.srch.GetDisplayNames(ctx, &search.GetDisplayNamesParams{Tokens: tokens})
+	if err != nil {
+		ctxutils.GetLogger(ctx).Warnf("[%s] failed to get display names: %v", op, err)
+	}
+
+	return convertTokenSubs(subs, names), nil
 }
 
 // UnsubscribeFromToken unsubscribe user from token updates
@@ -95,11 +114,13 @@ func (s *Service) UnsubscribeFromToken(ctx context.Context, id string) error {
 	return nil
 }
 
-// UpdateTokenSubParams represents parameters for updating token subscription
+// UpdateTokenSubParams represents parameters for updating token subscription,
+// current signal is kept if Signal is empty
 type UpdateTokenSubParams struct {
 	ID        string
 	Threshold float64
 	Method    string
+	Signal    string
 }
 
 // UpdateTokenSubResult represents new values of current and previous interest after updating token subscription
@@ -116,6 +137,7 @@ func (s *Service) UpdateTokenSubscription(ctx context.Context, params *UpdateTok
 		ID:        params.ID,
 		Threshold: params.Threshold,
 		Method:    params.Method,
+		Signal:    params.Signal,
 	})
 	if err != nil {
 		return nil, service.ParseRepositoryError(op, err)
@@ -124,17 +146,29 @@ func (s *Service) UpdateTokenSubscription(ctx context.Context, params *UpdateTok
 	return &UpdateTokenSubResult{CurrInterest: res.CurrInterest, PrvInterest: res.PrvInterest}, nil
 }
 
-// parseInterest return interest based on the chosen method
-func parseInterest(token *models.Token, method string) (int64, error) {
+// parseInterest return interest based on the chosen signal and method
+func parseInterest(token *models.Token, method, signal string) (int64, error) {
+	var value, globalMedian, categoryMedian int64
+
+	switch signal {
+	case signalInterest:
+		value, globalMedian, categoryMedian = token.Interest, token.GlobalMedian, token.CategoryMedian
+	case signalDocFrequency:
+		value, globalMedian, categoryMedian = token.DocFrequency, token.GlobalDocMedian, token.CategoryDocMedian
+	default:
+		return 0, errors.New("got unexpected signal")
+	}
+
 	var interest int64
 
+	// medians are limited from below, so zero medians of days with zero interest do not break the division
 	switch method {
 	case methodDenormalized:
-		interest = token.Interest
+		interest = value
 	case methodGlobalMedian:
-		interest = token.Interest / token.GlobalMedian
+		interest = value / max(globalMedian, 1)
 	case methodCategoryMedian:
-		interest = token.Interest / token.CategoryMedian
+		interest = value / max(categoryMedian, 1)
 	default:
 		return 0, errors.New("got unexpected method")
 	}
@@ -143,14 +177,21 @@ func parseInterest(token *models.Token, method string) (int64, error) {
 }
 
 // convertTokenSubs converts token subs from the repository layer to the service layer format
-func convertTokenSubs(subs []*models.UserTokenSub) []*TokenSubInfo {
+func convertTokenSubs(subs []*models.UserTokenSub, names map[string]string) []*TokenSubInfo {
 	res := make([]*TokenSubInfo, 0, len(subs))
 	for _, s := range subs {
+		displayName, ok := names[s.Token]
+		if !ok {
+			displayName = s.Token
+		}
+
 		res = append(res, &TokenSubInfo{
 			ID:               s.ID,
 			Token:            s.Token,
+			DisplayName:      displayName,
 			Category:         s.Category,
 			Method:           s.Method,
+			Signal:           s.Signal,
 			Threshold:        s.Threshold,
 			CurrentInterest:  s.CurrentInterest,
 			PreviousInterest: s.PreviousInterest,
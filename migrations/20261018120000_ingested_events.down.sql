@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ingested_events_scrape_date_idx;
+
+DROP TABLE IF EXISTS ingested_events;
@@ -0,0 +1,16 @@
+CREATE TABLE ingested_events
+(
+    fingerprint TEXT        NOT NULL,
+    site_name   TEXT        NOT NULL,
+    scrape_date TIMESTAMP   NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT ingested_events_pk PRIMARY KEY (fingerprint)
+);
+
+COMMENT ON COLUMN ingested_events.fingerprint IS 'Отпечаток события скрапера (хеш сайта, даты и сообщения)';
+COMMENT ON COLUMN ingested_events.site_name IS 'Название сайта';
+COMMENT ON COLUMN ingested_events.scrape_date IS 'Дата сбора данных';
+COMMENT ON COLUMN ingested_events.created_at IS 'Дата и время обработки события';
+
+CREATE INDEX ingested_events_scrape_date_idx ON ingested_events (scrape_date);
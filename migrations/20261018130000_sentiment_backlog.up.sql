@@ -0,0 +1,68 @@
+-- add sentiment pending marker to token_data table
+ALTER TABLE token_data
+ADD COLUMN sentiment_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN token_data.sentiment_pending IS 'Тональность еще не посчитана (LLM был недоступен)';
+
+CREATE INDEX token_data_sentiment_pending_idx ON token_data (token_id) WHERE sentiment_pending;
+
+-- create backlog of tokens waiting for sentiment analysis
+CREATE TABLE sentiment_backlog
+(
+    token_id   BIGINT      NOT NULL,
+    context    TEXT        NOT NULL,
+    attempts   INT         NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT sentiment_backlog_pk PRIMARY KEY (token_id),
+    CONSTRAINT sentiment_backlog_token_id_fkey FOREIGN KEY (token_id)
+        REFERENCES token_data (token_id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN sentiment_backlog.token_id IS 'Идентификатор токена, для которого нужно посчитать тональность';
+COMMENT ON COLUMN sentiment_backlog.context IS 'Контекст упоминаний токена';
+COMMENT ON COLUMN sentiment_backlog.attempts IS 'Количество неудачных попыток анализа тональности';
+COMMENT ON COLUMN sentiment_backlog.created_at IS 'Дата и время создания записи';
+
+CREATE INDEX sentiment_backlog_created_at_idx ON sentiment_backlog (created_at);
+
+-- recreate search mv, pending sentiment is not taken into account
+DROP INDEX IF EXISTS mv_token_search_pk;
+DROP INDEX IF EXISTS mv_token_search_trgm_idx;
+DROP INDEX IF EXISTS mv_token_search_interest_idx;
+DROP INDEX IF EXISTS mv_token_search_category_idx;
+DROP MATERIALIZED VIEW IF EXISTS mv_token_search;
+
+CREATE MATERIALIZED VIEW mv_token_search AS
+WITH
+    aggr AS (SELECT token_name,
+                    scrape_date,
+                    category,
+                    SUM(interest)                                                                     AS interest,
+                    COALESCE(ROUND(AVG(sentiment) FILTER (WHERE NOT sentiment_pending)), 0)::SMALLINT AS sentiment
+             FROM token_data
+             GROUP BY (token_name, scrape_date, category)),
+    global_medians AS (SELECT scrape_date,
+                              PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest) AS median_interest
+                       FROM aggr
+                       GROUP BY scrape_date),
+    category_medians AS (SELECT scrape_date,
+                                category,
+                                PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest) AS median_interest
+                         FROM aggr
+                         GROUP BY (scrape_date, category))
+SELECT a.token_name,
+       a.scrape_date,
+       a.interest,
+       a.sentiment,
+       a.category,
+       gm.median_interest AS global_median,
+       cm.median_interest AS category_median
+FROM aggr a
+         JOIN global_medians gm ON a.scrape_date = gm.scrape_date
+         JOIN category_medians cm ON a.scrape_date = cm.scrape_date AND a.category = cm.category;
+
+CREATE UNIQUE INDEX mv_token_search_pk ON mv_token_search (token_name, scrape_date, category);
+CREATE INDEX mv_token_search_trgm_idx ON mv_token_search USING GIN (token_name gin_trgm_ops);
+CREATE INDEX mv_token_search_interest_idx ON mv_token_search (interest DESC);
+CREATE INDEX mv_token_search_category_idx ON mv_token_search (category);
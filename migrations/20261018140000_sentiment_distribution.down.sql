@@ -0,0 +1,48 @@
+-- recreate previous search mv (copy sentiment_backlog up)
+DROP INDEX IF EXISTS mv_token_search_pk;
+DROP INDEX IF EXISTS mv_token_search_trgm_idx;
+DROP INDEX IF EXISTS mv_token_search_interest_idx;
+DROP INDEX IF EXISTS mv_token_search_category_idx;
+DROP MATERIALIZED VIEW IF EXISTS mv_token_search;
+
+CREATE MATERIALIZED VIEW mv_token_search AS
+WITH
+    aggr AS (SELECT token_name,
+                    scrape_date,
+                    category,
+                    SUM(interest)                                                                     AS interest,
+                    COALESCE(ROUND(AVG(sentiment) FILTER (WHERE NOT sentiment_pending)), 0)::SMALLINT AS sentiment
+             FROM token_data
+             GROUP BY (token_name, scrape_date, category)),
+    global_medians AS (SELECT scrape_date,
+                              PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest) AS median_interest
+                       FROM aggr
+                       GROUP BY scrape_date),
+    category_medians AS (SELECT scrape_date,
+                                category,
+                                PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest) AS median_interest
+                         FROM aggr
+                         GROUP BY (scrape_date, category))
+SELECT a.token_name,
+       a.scrape_date,
+       a.interest,
+       a.sentiment,
+       a.category,
+       gm.median_interest AS global_median,
+       cm.median_interest AS category_median
+FROM aggr a
+         JOIN global_medians gm ON a.scrape_date = gm.scrape_date
+         JOIN category_medians cm ON a.scrape_date = cm.scrape_date AND a.category = cm.category;
+
+CREATE UNIQUE INDEX mv_token_search_pk ON mv_token_search (token_name, scrape_date, category);
+CREATE INDEX mv_token_search_trgm_idx ON mv_token_search USING GIN (token_name gin_trgm_ops);
+CREATE INDEX mv_token_search_interest_idx ON mv_token_search (interest DESC);
+CREATE INDEX mv_token_search_category_idx ON mv_token_search (category);
+
+-- delete sentiment score, confidence and mentions
+ALTER TABLE token_data
+DROP COLUMN sentiment_score,
+DROP COLUMN sentiment_confidence,
+DROP COLUMN mentions_positive,
+DROP COLUMN mentions_neutral,
+DROP COLUMN mentions_negative;
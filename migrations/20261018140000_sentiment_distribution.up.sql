@@ -0,0 +1,72 @@
+-- add sentiment score, confidence and mentions to token_data table
+ALTER TABLE token_data
+ADD COLUMN sentiment_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN sentiment_confidence DOUBLE PRECISION,
+ADD COLUMN mentions_positive BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN mentions_neutral BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN mentions_negative BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN token_data.sentiment_score IS 'Непрерывная оценка тональности от -1 до 1';
+COMMENT ON COLUMN token_data.sentiment_confidence IS 'Уверенность в оценке тональности от 0 до 1 (NULL, если провайдер ее не возвращает)';
+COMMENT ON COLUMN token_data.mentions_positive IS 'Количество позитивных упоминаний токена';
+COMMENT ON COLUMN token_data.mentions_neutral IS 'Количество нейтральных упоминаний токена';
+COMMENT ON COLUMN token_data.mentions_negative IS 'Количество негативных упоминаний токена';
+
+-- fill existing rows from discrete sentiment, every mention gets the sentiment of the row
+UPDATE token_data
+SET sentiment_score   = sentiment,
+    mentions_positive = CASE WHEN sentiment > 0 THEN interest ELSE 0 END,
+    mentions_neutral  = CASE WHEN sentiment = 0 THEN interest ELSE 0 END,
+    mentions_negative = CASE WHEN sentiment < 0 THEN interest ELSE 0 END
+WHERE NOT sentiment_pending;
+
+-- recreate search mv with sentiment score, confidence and distribution of mentions
+DROP INDEX IF EXISTS mv_token_search_pk;
+DROP INDEX IF EXISTS mv_token_search_trgm_idx;
+DROP INDEX IF EXISTS mv_token_search_interest_idx;
+DROP INDEX IF EXISTS mv_token_search_category_idx;
+DROP MATERIALIZED VIEW IF EXISTS mv_token_search;
+
+CREATE MATERIALIZED VIEW mv_token_search AS
+WITH
+    aggr AS (SELECT token_name,
+                    scrape_date,
+                    category,
+                    SUM(interest)                                                                     AS interest,
+                    COALESCE(ROUND(AVG(sentiment) FILTER (WHERE NOT sentiment_pending)), 0)::SMALLINT AS sentiment,
+                    COALESCE(AVG(sentiment_score) FILTER (WHERE NOT sentiment_pending), 0)            AS sentiment_score,
+                    AVG(sentiment_confidence) FILTER (WHERE NOT sentiment_pending)                    AS sentiment_confidence,
+                    SUM(mentions_positive)::BIGINT                                                    AS mentions_positive,
+                    SUM(mentions_neutral)::BIGINT                                                     AS mentions_neutral,
+                    SUM(mentions_negative)::BIGINT                                                    AS mentions_negative
+             FROM token_data
+             GROUP BY (token_name, scrape_date, category)),
+    global_medians AS (SELECT scrape_date,
+                              PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest) AS median_interest
+                       FROM aggr
+                       GROUP BY scrape_date),
+    category_medians AS (SELECT scrape_date,
+                                category,
+                                PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest) AS median_interest
+                         FROM aggr
+                         GROUP BY (scrape_date, category))
+SELECT a.token_name,
+       a.scrape_date,
+       a.interest,
+       a.sentiment,
+       a.sentiment_score,
+       a.sentiment_confidence,
+       a.mentions_positive,
+       a.mentions_neutral,
+       a.mentions_negative,
+       a.category,
+       gm.median_interest AS global_median,
+       cm.median_interest AS category_median
+FROM aggr a
+         JOIN global_medians gm ON a.scrape_date = gm.scrape_date
+         JOIN category_medians cm ON a.scrape_date = cm.scrape_date AND a.category = cm.category;
+
+CREATE UNIQUE INDEX mv_token_search_pk ON mv_token_search (token_name, scrape_date, category);
+CREATE INDEX mv_token_search_trgm_idx ON mv_token_search USING GIN (token_name gin_trgm_ops);
+CREATE INDEX mv_token_search_interest_idx ON mv_token_search (interest DESC);
+CREATE INDEX mv_token_search_category_idx ON mv_token_search (category);
@@ -0,0 +1,14 @@
+CREATE TABLE token_surface_forms
+(
+    token_name   TEXT      NOT NULL,
+    scrape_date  TIMESTAMP NOT NULL,
+    surface_form TEXT      NOT NULL,
+    mentions     BIGINT    NOT NULL DEFAULT 0,
+
+    CONSTRAINT token_surface_forms_pk PRIMARY KEY (token_name, scrape_date, surface_form)
+);
+
+COMMENT ON COLUMN token_surface_forms.token_name IS 'Название токена (стем)';
+COMMENT ON COLUMN token_surface_forms.scrape_date IS 'Дата сбора данных';
+COMMENT ON COLUMN token_surface_forms.surface_form IS 'Исходная форма токена в тексте';
+COMMENT ON COLUMN token_surface_forms.mentions IS 'Количество упоминаний токена в этой форме';
@@ -0,0 +1,14 @@
+DROP VIEW IF EXISTS v_duplicate_stats;
+
+DROP INDEX IF EXISTS ingested_events_simhash_band_0_idx;
+DROP INDEX IF EXISTS ingested_events_simhash_band_1_idx;
+DROP INDEX IF EXISTS ingested_events_simhash_band_2_idx;
+DROP INDEX IF EXISTS ingested_events_simhash_band_3_idx;
+
+ALTER TABLE ingested_events
+    DROP COLUMN IF EXISTS simhash,
+    DROP COLUMN IF EXISTS simhash_band_0,
+    DROP COLUMN IF EXISTS simhash_band_1,
+    DROP COLUMN IF EXISTS simhash_band_2,
+    DROP COLUMN IF EXISTS simhash_band_3,
+    DROP COLUMN IF EXISTS duplicate;
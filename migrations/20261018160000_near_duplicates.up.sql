@@ -0,0 +1,30 @@
+ALTER TABLE ingested_events
+    ADD COLUMN simhash        BIGINT,
+    ADD COLUMN simhash_band_0 INTEGER,
+    ADD COLUMN simhash_band_1 INTEGER,
+    ADD COLUMN simhash_band_2 INTEGER,
+    ADD COLUMN simhash_band_3 INTEGER,
+    ADD COLUMN duplicate      BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN ingested_events.simhash IS 'SimHash отпечаток текста сообщения (NULL, если сообщение слишком короткое)';
+COMMENT ON COLUMN ingested_events.simhash_band_0 IS 'Биты 0-15 отпечатка для поиска похожих сообщений';
+COMMENT ON COLUMN ingested_events.simhash_band_1 IS 'Биты 16-31 отпечатка для поиска похожих сообщений';
+COMMENT ON COLUMN ingested_events.simhash_band_2 IS 'Биты 32-47 отпечатка для поиска похожих сообщений';
+COMMENT ON COLUMN ingested_events.simhash_band_3 IS 'Биты 48-63 отпечатка для поиска похожих сообщений';
+COMMENT ON COLUMN ingested_events.duplicate IS 'Сообщение является почти дубликатом ранее обработанного';
+
+CREATE INDEX ingested_events_simhash_band_0_idx ON ingested_events (simhash_band_0, scrape_date);
+CREATE INDEX ingested_events_simhash_band_1_idx ON ingested_events (simhash_band_1, scrape_date);
+CREATE INDEX ingested_events_simhash_band_2_idx ON ingested_events (simhash_band_2, scrape_date);
+CREATE INDEX ingested_events_simhash_band_3_idx ON ingested_events (simhash_band_3, scrape_date);
+
+CREATE VIEW v_duplicate_stats AS
+SELECT site_name,
+       scrape_date,
+       COUNT(*)                                AS messages,
+       COUNT(*) FILTER (WHERE duplicate)       AS duplicates,
+       COUNT(*) FILTER (WHERE simhash IS NULL) AS not_fingerprinted
+FROM ingested_events
+GROUP BY site_name, scrape_date;
+
+COMMENT ON VIEW v_duplicate_stats IS 'Статистика почти дубликатов сообщений по сайтам и датам';
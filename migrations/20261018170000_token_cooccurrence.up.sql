@@ -0,0 +1,18 @@
+CREATE TABLE token_cooccurrence
+(
+    token_name    TEXT      NOT NULL,
+    scrape_date   TIMESTAMP NOT NULL,
+    category      TEXT      NOT NULL,
+    related_token TEXT      NOT NULL,
+    mentions      BIGINT    NOT NULL DEFAULT 0,
+
+    CONSTRAINT token_cooccurrence_pk PRIMARY KEY (token_name, scrape_date, category, related_token)
+);
+
+COMMENT ON COLUMN token_cooccurrence.token_name IS 'Название токена';
+COMMENT ON COLUMN token_cooccurrence.scrape_date IS 'Дата сбора данных';
+COMMENT ON COLUMN token_cooccurrence.category IS 'Категория';
+COMMENT ON COLUMN token_cooccurrence.related_token IS 'Токен, встретившийся в окне контекста токена';
+COMMENT ON COLUMN token_cooccurrence.mentions IS 'Количество совместных упоминаний (каждая пара хранится в обе стороны)';
+
+CREATE INDEX token_cooccurrence_scrape_date_idx ON token_cooccurrence (scrape_date, category);
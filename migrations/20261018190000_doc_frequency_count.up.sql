@@ -0,0 +1,60 @@
+-- recreate search mv with doc frequency counted over token rows, every row is a single message
+DROP INDEX IF EXISTS mv_token_search_pk;
+DROP INDEX IF EXISTS mv_token_search_trgm_idx;
+DROP INDEX IF EXISTS mv_token_search_interest_idx;
+DROP INDEX IF EXISTS mv_token_search_category_idx;
+DROP MATERIALIZED VIEW IF EXISTS mv_token_search;
+
+CREATE MATERIALIZED VIEW mv_token_search AS
+WITH
+    aggr AS (SELECT token_name,
+                    scrape_date,
+                    category,
+                    SUM(interest)                                                                     AS interest,
+                    COUNT(*)::BIGINT                                                                  AS doc_frequency,
+                    COALESCE(ROUND(AVG(sentiment) FILTER (WHERE NOT sentiment_pending)), 0)::SMALLINT AS sentiment,
+                    COALESCE(AVG(sentiment_score) FILTER (WHERE NOT sentiment_pending), 0)            AS sentiment_score,
+                    AVG(sentiment_confidence) FILTER (WHERE NOT sentiment_pending)                    AS sentiment_confidence,
+                    SUM(mentions_positive)::BIGINT                                                    AS mentions_positive,
+                    SUM(mentions_neutral)::BIGINT                                                     AS mentions_neutral,
+                    SUM(mentions_negative)::BIGINT                                                    AS mentions_negative
+             FROM token_data
+             GROUP BY (token_name, scrape_date, category)),
+    global_medians AS (SELECT scrape_date,
+                              PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest)      AS median_interest,
+                              PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY doc_frequency) AS median_doc_frequency
+                       FROM aggr
+                       GROUP BY scrape_date),
+    category_medians AS (SELECT scrape_date,
+                                category,
+                                PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY interest)      AS median_interest,
+                                PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY doc_frequency) AS median_doc_frequency
+                         FROM aggr
+                         GROUP BY (scrape_date, category))
+SELECT a.token_name,
+       a.scrape_date,
+       a.interest,
+       a.doc_frequency,
+       a.sentiment,
+       a.sentiment_score,
+       a.sentiment_confidence,
+       a.mentions_positive,
+       a.mentions_neutral,
+       a.mentions_negative,
+       a.category,
+       gm.median_interest AS global_median,
+       cm.median_interest AS category_median,
+       gm.median_doc_frequency AS global_doc_median,
+       cm.median_doc_frequency AS category_doc_median
+FROM aggr a
+         JOIN global_medians gm ON a.scrape_date = gm.scrape_date
+         JOIN category_medians cm ON a.scrape_date = cm.scrape_date AND a.category = cm.category;
+
+CREATE UNIQUE INDEX mv_token_search_pk ON mv_token_search (token_name, scrape_date, category);
+CREATE INDEX mv_token_search_trgm_idx ON mv_token_search USING GIN (token_name gin_trgm_ops);
+CREATE INDEX mv_token_search_interest_idx ON mv_token_search (interest DESC);
+CREATE INDEX mv_token_search_category_idx ON mv_token_search (category);
+
+-- doc frequency is not written by processor anymore
+ALTER TABLE token_data
+DROP COLUMN IF EXISTS doc_frequency;
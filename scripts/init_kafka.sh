@@ -7,6 +7,17 @@ echo -e 'Creating kafka topics'
 kafka-topics --bootstrap-server ${KAFKA_HOSTNAME}:${KAFKA_DOCKER_PORT} --create --if-not-exists --topic ${KAFKA_SCRAPER_TOPIC} --replication-factor 1 --partitions 1
 kafka-topics --bootstrap-server ${KAFKA_HOSTNAME}:${KAFKA_DOCKER_PORT} --create --if-not-exists --topic ${KAFKA_NOTIFICATIONS_TOPIC} --replication-factor 1 --partitions 1
 
+# create retry topics
+for delay in 10s 1m 10m
+do
+  kafka-topics --bootstrap-server ${KAFKA_HOSTNAME}:${KAFKA_DOCKER_PORT} --create --if-not-exists --topic ${KAFKA_SCRAPER_TOPIC}.retry-${delay} --replication-factor 1 --partitions 1
+  kafka-topics --bootstrap-server ${KAFKA_HOSTNAME}:${KAFKA_DOCKER_PORT} --create --if-not-exists --topic ${KAFKA_NOTIFICATIONS_TOPIC}.retry-${delay} --replication-factor 1 --partitions 1
+done
+
+# create dead-letter topics
+kafka-topics --bootstrap-server ${KAFKA_HOSTNAME}:${KAFKA_DOCKER_PORT} --create --if-not-exists --topic ${KAFKA_SCRAPER_TOPIC}.dlq --replication-factor 1 --partitions 1
+kafka-topics --bootstrap-server ${KAFKA_HOSTNAME}:${KAFKA_DOCKER_PORT} --create --if-not-exists --topic ${KAFKA_NOTIFICATIONS_TOPIC}.dlq --replication-factor 1 --partitions 1
+
 echo -e 'Successfully created the following topics:'
 kafka-topics --bootstrap-server ${KAFKA_HOSTNAME}:${KAFKA_DOCKER_PORT} --list
 